@@ -0,0 +1,124 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/analysis"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// AntisciaServer provides services for the calculation of antiscia, contra-antiscia and the contacts between
+// points and antiscia.
+type AntisciaServer interface {
+	Antiscia(positions []domain.SinglePosition) ([]domain.AntisciaPosition, error)
+	AntisciaContacts(positions []domain.SinglePosition, orb float64) ([]domain.AntisciaContact, error)
+	InterAntisciaContacts(positionsA []domain.SinglePosition, positionsB []domain.SinglePosition, orb float64) ([]domain.AntisciaContact, error)
+}
+
+type AntisciaService struct {
+	antCalc analysis.AntisciaCalculator
+}
+
+func NewAntisciaService() *AntisciaService {
+	antCalculator := analysis.NewAntisciaCalculation()
+	return &AntisciaService{
+		antCalc: antCalculator,
+	}
+}
+
+const (
+	MinOrbAntiscia = 0.0
+	MaxOrbAntiscia = 10.0
+)
+
+// Antiscia handles the calculation of antiscia and contra-antiscia.
+// PRE: length positions >= 1
+// PRE: for all values for position in positions: 0.0 <= value < 360.0
+// POST: no errors -> returns positions with their antiscia and contra-antiscia
+// POST: contains errors -> returns nil and error
+func (as AntisciaService) Antiscia(positions []domain.SinglePosition) ([]domain.AntisciaPosition, error) {
+	slog.Info("Started calculation of antiscia")
+	if len(positions) < MinPointsPerChart {
+		slog.Error("Not enough positions")
+		return nil, errors.New("antiscia failed, not enough data")
+	}
+	if err := validateAntisciaPositions(positions); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed calculation of antiscia")
+	return as.antCalc.CalcAntiscia(positions)
+}
+
+// AntisciaContacts handles the search for points that occupy the antiscion or contra-antiscion of another point.
+// PRE: length positions >= 2
+// PRE: 0 < orb <= 10
+// PRE: for all values for position in positions: 0.0 <= value < 360.0
+// POST: no errors -> returns contacts
+// POST: contains errors -> returns nil and error
+func (as AntisciaService) AntisciaContacts(positions []domain.SinglePosition, orb float64) ([]domain.AntisciaContact, error) {
+	slog.Info("Started calculation of antiscia contacts")
+	if len(positions) < 2 {
+		slog.Error("Not enough positions")
+		return nil, errors.New("antiscia contacts failed, not enough data")
+	}
+	if err := validateAntisciaOrb(orb); err != nil {
+		return nil, err
+	}
+	if err := validateAntisciaPositions(positions); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed calculation of antiscia contacts")
+	return as.antCalc.CalcAntisciaContacts(positions, orb)
+}
+
+// InterAntisciaContacts handles the search for points in chart B that occupy the antiscion or contra-antiscion of
+// a point in chart A.
+// PRE: length positionsA >= 1
+// PRE: length positionsB >= 1
+// PRE: 0 < orb <= 10
+// PRE: for all values for position in positionsA and positionsB: 0.0 <= value < 360.0
+// POST: no errors -> returns contacts, Pos1 is from chart A and Pos2 from chart B
+// POST: contains errors -> returns nil and error
+func (as AntisciaService) InterAntisciaContacts(positionsA []domain.SinglePosition, positionsB []domain.SinglePosition, orb float64) ([]domain.AntisciaContact, error) {
+	slog.Info("Started calculation of inter-antiscia contacts")
+	if len(positionsA) < MinPointsPerChart || len(positionsB) < MinPointsPerChart {
+		slog.Error("Not enough positions")
+		return nil, errors.New("inter-antiscia contacts failed, not enough data")
+	}
+	if err := validateAntisciaOrb(orb); err != nil {
+		return nil, err
+	}
+	for _, positions := range [][]domain.SinglePosition{positionsA, positionsB} {
+		if err := validateAntisciaPositions(positions); err != nil {
+			return nil, err
+		}
+	}
+	slog.Info("Completed calculation of inter-antiscia contacts")
+	return as.antCalc.CalcInterAntisciaContacts(positionsA, positionsB, orb)
+}
+
+func validateAntisciaOrb(orb float64) error {
+	if orb <= MinOrbAntiscia || orb > MaxOrbAntiscia {
+		slog.Error("Orb out of range")
+		return fmt.Errorf("orb %f is out of range, must be > %f and <= %f", orb, MinOrbAntiscia, MaxOrbAntiscia)
+	}
+	return nil
+}
+
+func validateAntisciaPositions(positions []domain.SinglePosition) error {
+	for _, pos := range positions {
+		if pos.Position < domain.MinLongitude || pos.Position >= domain.MaxLongitude {
+			slog.Error("Position out of range")
+			return fmt.Errorf("position %f is out of range", pos.Position)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func TestAntisciaHappyFlow(t *testing.T) {
+	positions := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 170.5},
+	}
+	aService := NewAntisciaService()
+	result, err := aService.Antiscia(positions)
+	if err != nil {
+		t.Errorf("Antiscia returned unexpected error %v", err)
+	}
+	if len(result) != 2 {
+		t.Errorf("Antiscia expected 2 results, got %d", len(result))
+	}
+}
+
+func TestAntisciaPositionOutOfRange(t *testing.T) {
+	positions := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 360.0},
+	}
+	aService := NewAntisciaService()
+	result, err := aService.Antiscia(positions)
+	if err == nil {
+		t.Errorf("Expected error for position out of range, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for position out of range")
+	}
+}
+
+func TestAntisciaContactsHappyFlow(t *testing.T) {
+	positions := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 170.5},
+	}
+	aService := NewAntisciaService()
+	result, err := aService.AntisciaContacts(positions, 1.0)
+	if err != nil {
+		t.Errorf("AntisciaContacts returned unexpected error %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("AntisciaContacts expected 1 contact, got %d", len(result))
+	}
+}
+
+func TestAntisciaContactsOrbTooLarge(t *testing.T) {
+	positions := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 170.5},
+	}
+	aService := NewAntisciaService()
+	result, err := aService.AntisciaContacts(positions, 11.0)
+	if err == nil {
+		t.Errorf("Expected error for orb that was too large, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for orb that was too large")
+	}
+}
+
+func TestAntisciaContactsInsufficientData(t *testing.T) {
+	positions := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+	}
+	aService := NewAntisciaService()
+	result, err := aService.AntisciaContacts(positions, 1.0)
+	if err == nil {
+		t.Errorf("Expected error for insufficient data, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for insufficient data")
+	}
+}
+
+func TestInterAntisciaContactsHappyFlow(t *testing.T) {
+	positionsA := []domain.SinglePosition{{Id: domain.Sun, Position: 95.0}}
+	positionsB := []domain.SinglePosition{{Id: domain.Sun, Position: 84.6}}
+	aService := NewAntisciaService()
+	result, err := aService.InterAntisciaContacts(positionsA, positionsB, 1.0)
+	if err != nil {
+		t.Errorf("InterAntisciaContacts returned unexpected error %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("InterAntisciaContacts expected 1 contact, got %d", len(result))
+	}
+}
+
+func TestInterAntisciaContactsEmptyChart(t *testing.T) {
+	positionsA := []domain.SinglePosition{{Id: domain.Sun, Position: 95.0}}
+	aService := NewAntisciaService()
+	result, err := aService.InterAntisciaContacts(positionsA, []domain.SinglePosition{}, 1.0)
+	if err == nil {
+		t.Errorf("Expected error for empty chart, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for empty chart")
+	}
+}
@@ -21,9 +21,36 @@ type AspectServer interface {
 		aspects []domain.Aspect,
 		cfgPoints []domain.ConfigPoint,
 		cfgAspects []domain.ConfigAspect,
-		baseOrb float64) ([]domain.ActualAspect, error)
+		orbs domain.ConfigOrbs) ([]domain.ActualAspect, error)
+	MovingAspects(points []domain.PositionWithSpeed,
+		aspects []domain.Aspect,
+		cfgPoints []domain.ConfigPoint,
+		cfgAspects []domain.ConfigAspect,
+		orbs domain.ConfigOrbs) ([]domain.MovingAspect, error)
+	InterAspects(pointsA []domain.SinglePosition,
+		pointsB []domain.SinglePosition,
+		aspects []domain.Aspect,
+		cfgPoints []domain.ConfigPoint,
+		cfgAspects []domain.ConfigAspect,
+		orbs domain.ConfigOrbs) ([]domain.ActualAspect, error)
+	FramedAspects(points []domain.PointPosResult,
+		frame domain.AspectFrame,
+		armc float64,
+		geoLat float64,
+		aspects []domain.Aspect,
+		cfgPoints []domain.ConfigPoint,
+		cfgAspects []domain.ConfigAspect,
+		orbs domain.ConfigOrbs) ([]domain.FramedAspect, error)
+	OrbTable(aspects []domain.Aspect, config domain.Config) (domain.OrbTable, error)
+	AspectsWithOrbTable(points []domain.SinglePosition, table *domain.OrbTable) ([]domain.ActualAspect, error)
+	InterAspectsWithOrbTable(pointsA []domain.SinglePosition,
+		pointsB []domain.SinglePosition,
+		table *domain.OrbTable) ([]domain.ActualAspect, error)
 }
 
+// MinPointsPerChart is the minimal number of points for each chart in a comparison of two charts.
+const MinPointsPerChart = 1
+
 type AspectService struct {
 	aspCalc analysis.AspectsCalculator
 }
@@ -42,35 +69,298 @@ func NewAspectService() *AspectService {
 // PRE length cfgAspects >= 1
 // PRE each point is represented in cfgPoints
 // PRE each aspect is represented in cfgAspects
+// PRE for custom aspects: 0.0 < distance <= 180.0
 // PRE for all positions : 0.0 <= position < 360.0
+// PRE orbs.OrbMethod is a defined OrbMethod
+// PRE orbs.OrbLuminaryFactor > 0.0 if the orb method is OrbMethodLuminaries
+// PRE for all explicit orbs: orb > 0.0
 // POST no errors -> returns slice of occupied midpoints
 // POST errors: returns nil and error
 func (as AspectService) Aspects(points []domain.SinglePosition,
 	aspects []domain.Aspect,
 	cfgPoints []domain.ConfigPoint,
 	cfgAspects []domain.ConfigAspect,
-	baseOrb float64) ([]domain.ActualAspect, error) {
+	orbs domain.ConfigOrbs) ([]domain.ActualAspect, error) {
+
+	slog.Info("received request")
+	if err := validateAspectInput(points, aspects, cfgPoints, cfgAspects); err != nil {
+		return nil, err
+	}
+	if err := validateOrbs(orbs); err != nil {
+		return nil, err
+	}
+	// no errors in input, handle the calculation of aspects
+	slog.Info("completed calculation of aspects")
+	return as.aspCalc.CalcAspects(points, aspects, cfgPoints, cfgAspects, orbs)
+}
+
+// MovingAspects handles the calculation of aspects, including applying/separating and the days to exactness.
+// The speeds are the daily speeds in longitude, negative for retrograde movement.
+// PRE all PRE conditions for Aspects
+// POST no errors -> returns slice of moving aspects
+// POST errors: returns nil and error
+func (as AspectService) MovingAspects(points []domain.PositionWithSpeed,
+	aspects []domain.Aspect,
+	cfgPoints []domain.ConfigPoint,
+	cfgAspects []domain.ConfigAspect,
+	orbs domain.ConfigOrbs) ([]domain.MovingAspect, error) {
+	slog.Info("received request for moving aspects")
+	singlePoints := make([]domain.SinglePosition, 0, len(points))
+	for _, point := range points {
+		singlePoints = append(singlePoints, domain.SinglePosition{Id: point.Id, Position: point.Position})
+	}
+	if err := validateAspectInput(singlePoints, aspects, cfgPoints, cfgAspects); err != nil {
+		return nil, err
+	}
+	if err := validateOrbs(orbs); err != nil {
+		return nil, err
+	}
+	slog.Info("completed calculation of moving aspects")
+	return as.aspCalc.CalcMovingAspects(points, aspects, cfgPoints, cfgAspects, orbs)
+}
+
+// InterAspects handles the calculation of aspects between two charts, typically for synastry.
+// Only aspects between a point in chart A and a point in chart B are returned.
+// PRE length pointsA >= 1
+// PRE length pointsB >= 1
+// PRE all other PRE conditions for Aspects, for the combined points of both charts
+// POST no errors -> returns slice of aspects, Pos1 is from chart A and Pos2 from chart B
+// POST errors: returns nil and error
+func (as AspectService) InterAspects(pointsA []domain.SinglePosition,
+	pointsB []domain.SinglePosition,
+	aspects []domain.Aspect,
+	cfgPoints []domain.ConfigPoint,
+	cfgAspects []domain.ConfigAspect,
+	orbs domain.ConfigOrbs) ([]domain.ActualAspect, error) {
+	slog.Info("received request for inter-aspects")
+	if len(pointsA) < MinPointsPerChart || len(pointsB) < MinPointsPerChart {
+		slog.Error("not enough points in one of the charts")
+		return nil, errors.New("not enough points in one of the charts")
+	}
+	allPoints := make([]domain.SinglePosition, 0, len(pointsA)+len(pointsB))
+	allPoints = append(allPoints, pointsA...)
+	allPoints = append(allPoints, pointsB...)
+	if err := validateAspectInput(allPoints, aspects, cfgPoints, cfgAspects); err != nil {
+		return nil, err
+	}
+	if err := validateOrbs(orbs); err != nil {
+		return nil, err
+	}
+	slog.Info("completed calculation of inter-aspects")
+	return as.aspCalc.CalcInterAspects(pointsA, pointsB, aspects, cfgPoints, cfgAspects, orbs)
+}
 
+// FramedAspects handles the calculation of aspects in the coordinates defined by frame: longitude for
+// ecliptical, right ascension for equatorial, azimuth for horizontal and the proportional position in the semi-arcs
+// for mundane. The base orb is taken from orbs and depends on the frame, the orb method and the orb factors of points
+// and aspects are the same for all frames. Armc and geoLat are only used for the mundane frame.
+// PRE all PRE conditions for Aspects, the positions are checked for the selected frame
+// PRE frame is a defined AspectFrame
+// PRE 0.0 <= armc < 360.0
+// PRE -90.0 < geoLat < 90.0
+// PRE for mundane: no point is circumpolar
+// POST no errors -> returns slice of framed aspects, all with the given frame
+// POST errors: returns nil and error
+func (as AspectService) FramedAspects(points []domain.PointPosResult,
+	frame domain.AspectFrame,
+	armc float64,
+	geoLat float64,
+	aspects []domain.Aspect,
+	cfgPoints []domain.ConfigPoint,
+	cfgAspects []domain.ConfigAspect,
+	orbs domain.ConfigOrbs) ([]domain.FramedAspect, error) {
+	slog.Info("received request for framed aspects")
+	if int(frame) < 0 || int(frame) >= len(domain.AllAspectFrames()) {
+		slog.Error("unknown frame", "frame", frame)
+		return nil, fmt.Errorf("unknown frame %d", frame)
+	}
+	if armc < domain.MinArmc || armc >= domain.MaxArmc {
+		slog.Error("armc out of range")
+		return nil, fmt.Errorf("armc %f is out of range", armc)
+	}
+	if geoLat <= domain.MinGeoLat || geoLat >= domain.MaxGeoLat {
+		slog.Error("geographic latitude out of range")
+		return nil, fmt.Errorf("geographic latitude %f is out of range", geoLat)
+	}
+	singlePoints := make([]domain.SinglePosition, 0, len(points))
+	for _, point := range points {
+		pos := point.LonPos
+		switch frame {
+		case domain.FrameEquatorial, domain.FrameMundane:
+			pos = point.RaPos
+		case domain.FrameHorizontal:
+			pos = point.AzimPos
+		}
+		singlePoints = append(singlePoints, domain.SinglePosition{Id: point.Point, Position: pos})
+	}
+	if err := validateAspectInput(singlePoints, aspects, cfgPoints, cfgAspects); err != nil {
+		return nil, err
+	}
+	if err := validateOrbs(orbs); err != nil {
+		return nil, err
+	}
+	slog.Info("completed calculation of framed aspects")
+	return as.aspCalc.CalcFramedAspects(points, frame, armc, geoLat, aspects, cfgPoints, cfgAspects, orbs)
+}
+
+// OrbTable creates a table with precomputed orbs for the given aspects, based on the configured points, the
+// configured aspects including the custom aspects, the base orb for aspects and the orb method in config. The table
+// can be reused for any number of charts.
+// PRE length aspects >= 1
+// PRE length config.Points >= 2
+// PRE each aspect is represented in config.Aspects or config.CustomAspects
+// PRE for custom aspects: 0.0 < distance <= 180.0
+// PRE config.Orbs.OrbMethod is a defined OrbMethod
+// PRE config.Orbs.OrbLuminaryFactor > 0.0 if the orb method is OrbMethodLuminaries
+// PRE for all explicit orbs: orb > 0.0
+// POST no errors -> returns orb table
+// POST errors: returns empty table and error
+func (as AspectService) OrbTable(aspects []domain.Aspect, config domain.Config) (domain.OrbTable, error) {
+	slog.Info("received request for orb table")
+	const MinPointsForOrbTable = 2
+	if len(config.Points) < MinPointsForOrbTable {
+		slog.Error("not enough configured points")
+		return domain.OrbTable{}, errors.New("not enough configured points")
+	}
+	cfgAspects := make([]domain.ConfigAspect, 0, len(config.Aspects)+len(config.CustomAspects))
+	cfgAspects = append(cfgAspects, config.Aspects...)
+	cfgAspects = append(cfgAspects, config.CustomAspects...)
+	if err := validateAspects(aspects, cfgAspects); err != nil {
+		return domain.OrbTable{}, err
+	}
+	if err := validateOrbs(config.Orbs); err != nil {
+		return domain.OrbTable{}, err
+	}
+	slog.Info("completed creation of orb table")
+	return as.aspCalc.CreateOrbTable(aspects, config.Points, cfgAspects, config.Orbs), nil
+}
+
+// AspectsWithOrbTable handles the calculation of aspects, using a precomputed orb table. The results are the same
+// as for Aspects with the configuration that was used for the table.
+// PRE length points >= 2
+// PRE each point is represented in the table
+// PRE for all positions : 0.0 <= position < 360.0
+// POST no errors -> returns slice of actual aspects
+// POST errors: returns nil and error
+func (as AspectService) AspectsWithOrbTable(points []domain.SinglePosition, table *domain.OrbTable) ([]domain.ActualAspect, error) {
+	const MinPointsForCalcAsp = 2
+	if len(points) < MinPointsForCalcAsp {
+		slog.Error("not enough points")
+		return nil, errors.New("not enough points")
+	}
+	if err := validateOrbTablePoints(points, table); err != nil {
+		return nil, err
+	}
+	return as.aspCalc.CalcAspectsWithOrbTable(points, table)
+}
+
+// InterAspectsWithOrbTable handles the calculation of aspects between two charts, using a precomputed orb table.
+// PRE length pointsA >= 1
+// PRE length pointsB >= 1
+// PRE each point is represented in the table
+// PRE for all positions : 0.0 <= position < 360.0
+// POST no errors -> returns slice of actual aspects, Pos1 is from chart A and Pos2 from chart B
+// POST errors: returns nil and error
+func (as AspectService) InterAspectsWithOrbTable(pointsA []domain.SinglePosition,
+	pointsB []domain.SinglePosition,
+	table *domain.OrbTable) ([]domain.ActualAspect, error) {
+	if len(pointsA) < MinPointsPerChart || len(pointsB) < MinPointsPerChart {
+		slog.Error("not enough points in one of the charts")
+		return nil, errors.New("not enough points in one of the charts")
+	}
+	if err := validateOrbTablePoints(append(append([]domain.SinglePosition{}, pointsA...), pointsB...), table); err != nil {
+		return nil, err
+	}
+	return as.aspCalc.CalcInterAspectsWithOrbTable(pointsA, pointsB, table)
+}
+
+func validateOrbTablePoints(points []domain.SinglePosition, table *domain.OrbTable) error {
+	if table == nil || len(table.Orbs) == 0 {
+		slog.Error("orb table not initialized")
+		return errors.New("orb table not initialized")
+	}
+	for _, point := range points {
+		if _, found := table.PointIndex[point.Id]; !found {
+			slog.Error("point not found in orb table", "point", point.Id)
+			return fmt.Errorf("point %d not found in orb table", point.Id)
+		}
+		if point.Position >= domain.MaxLongitude || point.Position < domain.MinLongitude {
+			slog.Error("point is out of range", "longitude", fmt.Sprint(point.Position))
+			return fmt.Errorf("point %d is out of range, longitude is %f and should be >= %f and < %f",
+				point.Id, point.Position, domain.MinLongitude, domain.MaxLongitude)
+		}
+	}
+	return nil
+}
+
+func validateOrbs(orbs domain.ConfigOrbs) error {
+	if int(orbs.OrbMethod) < 0 || int(orbs.OrbMethod) >= len(domain.AllOrbMethods()) {
+		slog.Error("unknown orb method", "method", orbs.OrbMethod)
+		return fmt.Errorf("unknown orb method %d", orbs.OrbMethod)
+	}
+	if orbs.OrbMethod == domain.OrbMethodLuminaries && orbs.OrbLuminaryFactor <= 0.0 {
+		slog.Error("orb factor for luminaries must be positive")
+		return fmt.Errorf("orb factor for luminaries %f must be > 0.0", orbs.OrbLuminaryFactor)
+	}
+	for _, explicitOrb := range orbs.ExplicitOrbs {
+		if explicitOrb.Orb <= 0.0 {
+			slog.Error("explicit orb must be positive")
+			return fmt.Errorf("explicit orb %f must be > 0.0", explicitOrb.Orb)
+		}
+	}
+	return nil
+}
+
+func validateAspects(aspects []domain.Aspect, cfgAspects []domain.ConfigAspect) error {
+	const MinAspectsForCalcAsp = 1
+	if len(aspects) < MinAspectsForCalcAsp {
+		slog.Error("nog enough aspects")
+		return errors.New("not enough aspects")
+	}
+	const MaxCustomDistance = 180.0
+	for _, aspect := range aspects {
+		if aspect < 0 || (aspect < domain.FirstCustomAspect && int(aspect) >= len(domain.AllAspects())) {
+			slog.Error("unknown aspect", "aspect", aspect)
+			return fmt.Errorf("aspect %d is unknown", aspect)
+		}
+		match := false
+		for _, cfgAspect := range cfgAspects {
+			if aspect == cfgAspect.ActualAspect {
+				match = true
+				if aspect >= domain.FirstCustomAspect && (cfgAspect.Distance <= 0.0 || cfgAspect.Distance > MaxCustomDistance) {
+					slog.Error("distance for custom aspect out of range", "aspect", aspect)
+					return fmt.Errorf("distance %f for custom aspect %d should be > 0.0 and <= %f",
+						cfgAspect.Distance, aspect, MaxCustomDistance)
+				}
+			}
+		}
+		if !match {
+			slog.Error("aspect not found in configured aspects", "aspect", aspect)
+			return fmt.Errorf("aspect %d not found in configured aspects", aspect)
+		}
+	}
+	return nil
+}
+
+func validateAspectInput(points []domain.SinglePosition,
+	aspects []domain.Aspect,
+	cfgPoints []domain.ConfigPoint,
+	cfgAspects []domain.ConfigAspect) error {
 	const (
 		MinPointsForCalcAsp  = 2
 		MinAspectsForCalcAsp = 1
 	)
-	slog.Info("received request")
 	if len(points) < MinPointsForCalcAsp {
 		slog.Error("not enough points")
-		return nil, errors.New("not enough points")
+		return errors.New("not enough points")
 	}
 	if len(cfgPoints) < MinPointsForCalcAsp {
 		slog.Error("not enough configured points")
-		return nil, errors.New("not enough configured points")
-	}
-	if len(aspects) < MinAspectsForCalcAsp {
-		slog.Error("nog enough aspects")
-		return nil, errors.New("not enough aspects")
+		return errors.New("not enough configured points")
 	}
 	if len(cfgAspects) < MinAspectsForCalcAsp {
 		slog.Error("not enough configured aspects")
-		return nil, errors.New("not enough configured aspects")
+		return errors.New("not enough configured aspects")
 	}
 	var match bool
 	// check if points are available as configured point
@@ -83,31 +373,20 @@ func (as AspectService) Aspects(points []domain.SinglePosition,
 		}
 		if !match {
 			slog.Error("point not found in configured points", "point", point.Id)
-			return nil, fmt.Errorf("point %d not found in configured points", point.Id)
+			return fmt.Errorf("point %d not found in configured points", point.Id)
 		}
 	}
 	// check if aspects are available as configured aspect
-	for _, aspect := range aspects {
-		match = false
-		for _, cfgAspect := range cfgAspects {
-			if aspect == cfgAspect.ActualAspect {
-				match = true
-			}
-		}
-		if !match {
-			slog.Error("aspect not found in configured aspects", "aspect", aspect)
-			return nil, fmt.Errorf("aspect %d not found in configured aspects", aspect)
-		}
+	if err := validateAspects(aspects, cfgAspects); err != nil {
+		return err
 	}
 	// check if positions are within range
 	for _, point := range points {
 		if point.Position > domain.MaxLongitude || point.Position < domain.MinLongitude {
 			slog.Error("point is out of range", "longitude", fmt.Sprint(point.Position))
-			return nil, fmt.Errorf("point %d is out of range, longitude is %f and should be >= %f and < %f",
+			return fmt.Errorf("point %d is out of range, longitude is %f and should be >= %f and < %f",
 				point.Id, point.Position, domain.MinLongitude, domain.MaxLongitude)
 		}
 	}
-	// no errors in input, handle the calculation of aspects
-	slog.Info("completed calculation of aspects")
-	return as.aspCalc.CalcAspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	return nil
 }
@@ -9,11 +9,13 @@ package apianalysis
 
 import (
 	"enigma-ar/domain"
+	"enigma-ar/internal/meta"
+	"math"
 	"testing"
 )
 
 func TestAspectsNotEnoughPoints(t *testing.T) {
-	baseOrb := 10.0
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
 
 	var points = []domain.SinglePosition{
 		{Id: 0, Position: 100.0},
@@ -47,7 +49,7 @@ func TestAspectsNotEnoughPoints(t *testing.T) {
 	}
 
 	aspS := NewAspectService()
-	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, orbs)
 
 	if err == nil {
 		t.Errorf("Aspectsshould have returned an error for not enough points")
@@ -58,7 +60,7 @@ func TestAspectsNotEnoughPoints(t *testing.T) {
 }
 
 func TestAspectsNoAspects(t *testing.T) {
-	baseOrb := 10.0
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
 
 	var points = []domain.SinglePosition{
 		{Id: 0, Position: 100.0},
@@ -92,7 +94,7 @@ func TestAspectsNoAspects(t *testing.T) {
 	}
 
 	aspS := NewAspectService()
-	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, orbs)
 
 	if err == nil {
 		t.Errorf("Aspectsshould have returned an error for not enough aspects")
@@ -103,7 +105,7 @@ func TestAspectsNoAspects(t *testing.T) {
 }
 
 func TestAspectsNotEnoughConfigPoints(t *testing.T) {
-	baseOrb := 10.0
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
 
 	var points = []domain.SinglePosition{
 		{Id: 0, Position: 100.0},
@@ -137,7 +139,7 @@ func TestAspectsNotEnoughConfigPoints(t *testing.T) {
 	}
 
 	aspS := NewAspectService()
-	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, orbs)
 
 	if err == nil {
 		t.Errorf("Aspectsshould have returned an error for not enough config points")
@@ -148,7 +150,7 @@ func TestAspectsNotEnoughConfigPoints(t *testing.T) {
 }
 
 func TestAspectsNoConfigAspects(t *testing.T) {
-	baseOrb := 10.0
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
 
 	var points = []domain.SinglePosition{
 		{Id: 0, Position: 100.0},
@@ -181,7 +183,7 @@ func TestAspectsNoConfigAspects(t *testing.T) {
 	var cfgAspects []domain.ConfigAspect
 
 	aspS := NewAspectService()
-	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, orbs)
 
 	if err == nil {
 		t.Errorf("Aspectsshould have returned an error for not enough configaspects")
@@ -192,7 +194,7 @@ func TestAspectsNoConfigAspects(t *testing.T) {
 }
 
 func TestAspectsMissingConfigPoint(t *testing.T) {
-	baseOrb := 10.0
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
 
 	var points = []domain.SinglePosition{
 		{Id: 0, Position: 100.0},
@@ -231,7 +233,7 @@ func TestAspectsMissingConfigPoint(t *testing.T) {
 	}
 
 	aspS := NewAspectService()
-	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, orbs)
 
 	if err == nil {
 		t.Errorf("Aspectsshould have returned an error for missing configpoints")
@@ -242,7 +244,7 @@ func TestAspectsMissingConfigPoint(t *testing.T) {
 }
 
 func TestAspectsMissingConfigAspect(t *testing.T) {
-	baseOrb := 10.0
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
 
 	var points = []domain.SinglePosition{
 		{Id: 0, Position: 100.0},
@@ -281,7 +283,7 @@ func TestAspectsMissingConfigAspect(t *testing.T) {
 	}
 
 	aspS := NewAspectService()
-	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, orbs)
 
 	if err == nil {
 		t.Errorf("Aspectsshould have returned an error for missing config aspects")
@@ -292,7 +294,7 @@ func TestAspectsMissingConfigAspect(t *testing.T) {
 }
 
 func TestAspectsPositionTooLarge(t *testing.T) {
-	baseOrb := 10.0
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
 
 	var points = []domain.SinglePosition{
 		{Id: 0, Position: 100.0},
@@ -332,7 +334,7 @@ func TestAspectsPositionTooLarge(t *testing.T) {
 	}
 
 	aspS := NewAspectService()
-	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, orbs)
 
 	if err == nil {
 		t.Errorf("Aspectsshould have returned an error for a position that is too large")
@@ -343,7 +345,7 @@ func TestAspectsPositionTooLarge(t *testing.T) {
 }
 
 func TestAspectsPositionTooSmall(t *testing.T) {
-	baseOrb := 10.0
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
 
 	var points = []domain.SinglePosition{
 		{Id: 0, Position: 100.0},
@@ -383,7 +385,7 @@ func TestAspectsPositionTooSmall(t *testing.T) {
 	}
 
 	aspS := NewAspectService()
-	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, orbs)
 
 	if err == nil {
 		t.Errorf("Aspectsshould have returned an error for a position that is too small")
@@ -392,3 +394,449 @@ func TestAspectsPositionTooSmall(t *testing.T) {
 		t.Errorf("Aspects should have returned nil for a position that is too small")
 	}
 }
+
+func TestAspectsUsesOrbMethod(t *testing.T) {
+	var points = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+		{Id: 6, Position: 106.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100}, // Sun
+		{ActualPoint: 6, OrbFactor: 60},  // Saturn
+	}
+	var aspects = []domain.Aspect{0}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 0, OrbFactor: 100}, // conjunction
+	}
+	aspS := NewAspectService()
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects,
+		domain.ConfigOrbs{BaseOrbAspects: 10.0, OrbMethod: domain.OrbMethodMaxFactor})
+	if err != nil || len(result) != 1 {
+		t.Errorf("Aspects expected a conjunction with orb 10.0, got %v and error %v", result, err)
+	}
+	result, err = aspS.Aspects(points, aspects, cfgPoints, cfgAspects,
+		domain.ConfigOrbs{BaseOrbAspects: 10.0, OrbMethod: domain.OrbMethodMoietiesAverage})
+	if err != nil || len(result) != 0 {
+		t.Errorf("Aspects expected no conjunction with orb 4.0, got %v and error %v", result, err)
+	}
+}
+
+func TestAspectsUnknownOrbMethod(t *testing.T) {
+	var points = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+		{Id: 1, Position: 106.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100}, // Sun
+		{ActualPoint: 1, OrbFactor: 100}, // Moon
+	}
+	var aspects = []domain.Aspect{0}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 0, OrbFactor: 100}, // conjunction
+	}
+	aspS := NewAspectService()
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects,
+		domain.ConfigOrbs{BaseOrbAspects: 10.0, OrbMethod: domain.OrbMethod(99)})
+	if err == nil {
+		t.Errorf("Aspects should have returned an error for an unknown orb method")
+	}
+	if result != nil {
+		t.Errorf("Aspects should have returned nil for an unknown orb method")
+	}
+}
+
+func TestMovingAspectsHappyFlow(t *testing.T) {
+	var points = []domain.PositionWithSpeed{
+		{Id: 0, Position: 100.0, Speed: 1.0},
+		{Id: 1, Position: 95.0, Speed: 13.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: '\uE200'}, // Sun
+		{ActualPoint: 1, OrbFactor: 100, Glyph: '\uE201'}, // Moon
+	}
+	var aspects = []domain.Aspect{0}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 0, OrbFactor: 100, Glyph: '\uE700'}, // conjunction
+	}
+	aspS := NewAspectService()
+	result, err := aspS.MovingAspects(points, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	if err != nil {
+		t.Fatalf("MovingAspects returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Movement != domain.MovementApplying {
+		t.Errorf("MovingAspects expected one applying aspect, got %v", result)
+	}
+}
+
+func TestMovingAspectsPositionTooLarge(t *testing.T) {
+	var points = []domain.PositionWithSpeed{
+		{Id: 0, Position: 100.0, Speed: 1.0},
+		{Id: 1, Position: 360.5, Speed: 13.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: '\uE200'}, // Sun
+		{ActualPoint: 1, OrbFactor: 100, Glyph: '\uE201'}, // Moon
+	}
+	var aspects = []domain.Aspect{0}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 0, OrbFactor: 100, Glyph: '\uE700'}, // conjunction
+	}
+	aspS := NewAspectService()
+	result, err := aspS.MovingAspects(points, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	if err == nil {
+		t.Errorf("MovingAspects should have returned an error for a position that is too large")
+	}
+	if result != nil {
+		t.Errorf("MovingAspects should have returned nil for a position that is too large")
+	}
+}
+
+func TestInterAspectsEmptyChart(t *testing.T) {
+	var pointsA = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+		{Id: 1, Position: 164.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: '\uE200'}, // Sun
+		{ActualPoint: 1, OrbFactor: 100, Glyph: '\uE201'}, // Moon
+	}
+	var aspects = []domain.Aspect{0}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 0, OrbFactor: 100, Glyph: '\uE700'}, // conjunction
+	}
+	aspS := NewAspectService()
+	result, err := aspS.InterAspects(pointsA, []domain.SinglePosition{}, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	if err == nil {
+		t.Errorf("InterAspects should have returned an error for an empty chart")
+	}
+	if result != nil {
+		t.Errorf("InterAspects should have returned nil for an empty chart")
+	}
+}
+
+func TestInterAspectsMissingConfigPoint(t *testing.T) {
+	var pointsA = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+	}
+	var pointsB = []domain.SinglePosition{
+		{Id: 2, Position: 280.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: '\uE200'}, // Sun
+		{ActualPoint: 1, OrbFactor: 100, Glyph: '\uE201'}, // Moon
+	}
+	var aspects = []domain.Aspect{1}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 1, OrbFactor: 100, Glyph: '\uE710'}, // opposition
+	}
+	aspS := NewAspectService()
+	result, err := aspS.InterAspects(pointsA, pointsB, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	if err == nil {
+		t.Errorf("InterAspects should have returned an error for a point of chart B that is not configured")
+	}
+	if result != nil {
+		t.Errorf("InterAspects should have returned nil for a point of chart B that is not configured")
+	}
+}
+
+func TestInterAspectsHappyFlow(t *testing.T) {
+	var pointsA = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+	}
+	var pointsB = []domain.SinglePosition{
+		{Id: 0, Position: 280.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: '\uE200'}, // Sun
+		{ActualPoint: 1, OrbFactor: 100, Glyph: '\uE201'}, // Moon
+	}
+	var aspects = []domain.Aspect{1}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 1, OrbFactor: 100, Glyph: '\uE710'}, // opposition
+	}
+	aspS := NewAspectService()
+	result, err := aspS.InterAspects(pointsA, pointsB, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	if err != nil {
+		t.Fatalf("InterAspects returned unexpected error %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("InterAspects expected 1 aspect, got %v", result)
+	}
+}
+
+func TestFramedAspectsUsesOrbForFrame(t *testing.T) {
+	var points = []domain.PointPosResult{
+		{Point: 0, LonPos: 100.0, RaPos: 100.0},
+		{Point: 1, LonPos: 106.0, RaPos: 106.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: '\uE200'}, // Sun
+		{ActualPoint: 1, OrbFactor: 100, Glyph: '\uE201'}, // Moon
+	}
+	var aspects = []domain.Aspect{0}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 0, OrbFactor: 100, Glyph: '\uE700'}, // conjunction
+	}
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 8.0, BaseOrbAspectsEquatorial: 5.0}
+	aspS := NewAspectService()
+	result, err := aspS.FramedAspects(points, domain.FrameEcliptical, 0.0, 52.0, aspects, cfgPoints, cfgAspects, orbs)
+	if err != nil {
+		t.Fatalf("FramedAspects returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Frame != domain.FrameEcliptical {
+		t.Errorf("FramedAspects expected one ecliptical aspect, got %v", result)
+	}
+	result, err = aspS.FramedAspects(points, domain.FrameEquatorial, 0.0, 52.0, aspects, cfgPoints, cfgAspects, orbs)
+	if err != nil {
+		t.Fatalf("FramedAspects returned unexpected error %v", err)
+	}
+	if len(result) != 0 {
+		t.Errorf("FramedAspects expected no equatorial aspect, got %v", result)
+	}
+}
+
+func TestFramedAspectsUnknownFrame(t *testing.T) {
+	var points = []domain.PointPosResult{
+		{Point: 0, LonPos: 100.0},
+		{Point: 1, LonPos: 106.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: '\uE200'}, // Sun
+		{ActualPoint: 1, OrbFactor: 100, Glyph: '\uE201'}, // Moon
+	}
+	var aspects = []domain.Aspect{0}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 0, OrbFactor: 100, Glyph: '\uE700'}, // conjunction
+	}
+	aspS := NewAspectService()
+	result, err := aspS.FramedAspects(points, domain.AspectFrame(9), 0.0, 52.0, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{})
+	if err == nil {
+		t.Errorf("FramedAspects should have returned an error for an unknown frame")
+	}
+	if result != nil {
+		t.Errorf("FramedAspects should have returned nil for an unknown frame")
+	}
+}
+
+func TestFramedAspectsLatitudeOutOfRange(t *testing.T) {
+	var points = []domain.PointPosResult{
+		{Point: 0, RaPos: 100.0},
+		{Point: 1, RaPos: 190.0},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: '\uE200'}, // Sun
+		{ActualPoint: 1, OrbFactor: 100, Glyph: '\uE201'}, // Moon
+	}
+	var aspects = []domain.Aspect{0}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: 0, OrbFactor: 100, Glyph: '\uE700'}, // conjunction
+	}
+	aspS := NewAspectService()
+	result, err := aspS.FramedAspects(points, domain.FrameMundane, 100.0, 90.0, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{})
+	if err == nil {
+		t.Errorf("FramedAspects should have returned an error for a latitude out of range")
+	}
+	if result != nil {
+		t.Errorf("FramedAspects should have returned nil for a latitude out of range")
+	}
+}
+
+func TestAspectsWithOrbTableHappyFlow(t *testing.T) {
+	var points = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+		{Id: 1, Position: 164.0},
+	}
+	config := domain.Config{
+		Orbs: domain.ConfigOrbs{BaseOrbAspects: 10.0},
+		Points: []domain.ConfigPoint{
+			{ActualPoint: 0, OrbFactor: 100}, // Sun
+			{ActualPoint: 1, OrbFactor: 100}, // Moon
+		},
+		Aspects: []domain.ConfigAspect{
+			{ActualAspect: 5, OrbFactor: 60}, // sextile
+		},
+	}
+	aspS := NewAspectService()
+	table, err := aspS.OrbTable([]domain.Aspect{5}, config)
+	if err != nil {
+		t.Fatalf("OrbTable returned unexpected error %v", err)
+	}
+	result, err := aspS.AspectsWithOrbTable(points, &table)
+	if err != nil {
+		t.Fatalf("AspectsWithOrbTable returned unexpected error %v", err)
+	}
+	expected, _ := aspS.Aspects(points, []domain.Aspect{5}, config.Points, config.Aspects, config.Orbs)
+	if len(result) != 1 || result[0] != expected[0] {
+		t.Errorf("AspectsWithOrbTable expected %v, got %v", expected, result)
+	}
+}
+
+func TestOrbTableMissingConfigAspect(t *testing.T) {
+	config := domain.Config{
+		Points: []domain.ConfigPoint{
+			{ActualPoint: 0, OrbFactor: 100}, // Sun
+			{ActualPoint: 1, OrbFactor: 100}, // Moon
+		},
+		Aspects: []domain.ConfigAspect{
+			{ActualAspect: 5, OrbFactor: 60}, // sextile
+		},
+	}
+	aspS := NewAspectService()
+	_, err := aspS.OrbTable([]domain.Aspect{0}, config)
+	if err == nil {
+		t.Errorf("OrbTable should have returned an error for a missing configured aspect")
+	}
+}
+
+func TestAspectsWithOrbTableMissingPoint(t *testing.T) {
+	var points = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+		{Id: 2, Position: 164.0},
+	}
+	config := domain.Config{
+		Points: []domain.ConfigPoint{
+			{ActualPoint: 0, OrbFactor: 100}, // Sun
+			{ActualPoint: 1, OrbFactor: 100}, // Moon
+		},
+		Aspects: []domain.ConfigAspect{
+			{ActualAspect: 5, OrbFactor: 60}, // sextile
+		},
+	}
+	aspS := NewAspectService()
+	table, _ := aspS.OrbTable([]domain.Aspect{5}, config)
+	result, err := aspS.AspectsWithOrbTable(points, &table)
+	if err == nil {
+		t.Errorf("AspectsWithOrbTable should have returned an error for a point that is not in the table")
+	}
+	if result != nil {
+		t.Errorf("AspectsWithOrbTable should have returned nil for a point that is not in the table")
+	}
+}
+
+func TestInterAspectsWithOrbTableNoTable(t *testing.T) {
+	var points = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+	}
+	aspS := NewAspectService()
+	result, err := aspS.InterAspectsWithOrbTable(points, points, nil)
+	if err == nil {
+		t.Errorf("InterAspectsWithOrbTable should have returned an error for a missing table")
+	}
+	if result != nil {
+		t.Errorf("InterAspectsWithOrbTable should have returned nil for a missing table")
+	}
+}
+
+func TestOrbTableUnknownOrbMethod(t *testing.T) {
+	config := domain.Config{
+		Orbs: domain.ConfigOrbs{BaseOrbAspects: 10.0, OrbMethod: domain.OrbMethod(99)},
+		Points: []domain.ConfigPoint{
+			{ActualPoint: 0, OrbFactor: 100}, // Sun
+			{ActualPoint: 1, OrbFactor: 100}, // Moon
+		},
+		Aspects: []domain.ConfigAspect{
+			{ActualAspect: 5, OrbFactor: 60}, // sextile
+		},
+	}
+	aspS := NewAspectService()
+	_, err := aspS.OrbTable([]domain.Aspect{5}, config)
+	if err == nil {
+		t.Errorf("OrbTable should have returned an error for an unknown orb method")
+	}
+}
+
+func TestOrbTableZeroExplicitOrb(t *testing.T) {
+	config := domain.Config{
+		Orbs: domain.ConfigOrbs{
+			BaseOrbAspects: 10.0,
+			OrbMethod:      domain.OrbMethodExplicit,
+			ExplicitOrbs: []domain.ConfigExplicitOrb{
+				{Point1: 0, Point2: 1, ActualAspect: 5, Orb: 0.0},
+			},
+		},
+		Points: []domain.ConfigPoint{
+			{ActualPoint: 0, OrbFactor: 100}, // Sun
+			{ActualPoint: 1, OrbFactor: 100}, // Moon
+		},
+		Aspects: []domain.ConfigAspect{
+			{ActualAspect: 5, OrbFactor: 60}, // sextile
+		},
+	}
+	aspS := NewAspectService()
+	_, err := aspS.OrbTable([]domain.Aspect{5}, config)
+	if err == nil {
+		t.Errorf("OrbTable should have returned an error for an explicit orb of zero")
+	}
+}
+
+func TestAspectsCustomAspectDistanceOutOfRange(t *testing.T) {
+	var points = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+		{Id: 1, Position: 127.7},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100}, // Sun
+		{ActualPoint: 1, OrbFactor: 100}, // Moon
+	}
+	var aspects = []domain.Aspect{domain.FirstCustomAspect}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: domain.FirstCustomAspect, OrbFactor: 10, Name: "Custom", Distance: 200.0},
+	}
+	aspS := NewAspectService()
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	if err == nil {
+		t.Errorf("Aspects should have returned an error for a custom aspect with a distance out of range")
+	}
+	if result != nil {
+		t.Errorf("Aspects should have returned nil for a custom aspect with a distance out of range")
+	}
+}
+
+func TestAspectsCustomAspectHappyFlow(t *testing.T) {
+	var points = []domain.SinglePosition{
+		{Id: 0, Position: 100.0},
+		{Id: 1, Position: 127.7},
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100}, // Sun
+		{ActualPoint: 1, OrbFactor: 100}, // Moon
+	}
+	var aspects = []domain.Aspect{domain.FirstCustomAspect}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: domain.FirstCustomAspect, OrbFactor: 10, Name: "Tredecile", Distance: 360.0 / 13.0},
+	}
+	aspS := NewAspectService()
+	result, err := aspS.Aspects(points, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	if err != nil {
+		t.Fatalf("Aspects returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].ActualAspect != domain.FirstCustomAspect {
+		t.Errorf("Aspects expected one custom aspect, got %v", result)
+	}
+}
+
+func TestOrbTableCustomAspectFromConfig(t *testing.T) {
+	config, err := meta.ActualConfig([]string{
+		domain.CfgCustomAspectX + "1000=name:Tredecile|distance:27.692307692307693|use:true|show:false|factor:5.000000|glyph:65|color:{128 0 128 255}",
+	})
+	if err != nil {
+		t.Fatalf("ActualConfig returned unexpected error %v", err)
+	}
+	var points = []domain.SinglePosition{
+		{Id: domain.Sun, Position: 100.0},
+		{Id: domain.Moon, Position: 127.9},
+	}
+	aspS := NewAspectService()
+	table, err := aspS.OrbTable([]domain.Aspect{domain.FirstCustomAspect}, config)
+	if err != nil {
+		t.Fatalf("OrbTable returned unexpected error %v", err)
+	}
+	result, err := aspS.AspectsWithOrbTable(points, &table)
+	if err != nil {
+		t.Fatalf("AspectsWithOrbTable returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].ActualAspect != domain.FirstCustomAspect ||
+		math.Abs(result[0].ActualOrb-(27.9-360.0/13.0)) > 1e-8 {
+		t.Errorf("AspectsWithOrbTable expected the custom aspect, got %v", result)
+	}
+}
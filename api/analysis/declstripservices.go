@@ -0,0 +1,76 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/analysis"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// DeclStripServer provides the data for a declination strip and a declination diagram.
+type DeclStripServer interface {
+	DeclinationStrip(positions []domain.DoublePosition, jdUt float64, orb float64) (domain.DeclinationStrip, error)
+}
+
+type DeclStripService struct {
+	dsCalc analysis.DeclStripCalculator
+}
+
+func NewDeclStripService() *DeclStripService {
+	dsCalculator := analysis.NewDeclStripCalculation()
+	return &DeclStripService{
+		dsCalc: dsCalculator,
+	}
+}
+
+const (
+	MinOrbDeclStrip = 0.0
+	MaxOrbDeclStrip = 10.0
+)
+
+// DeclinationStrip handles the calculation of the data for a declination strip or diagram: the points sorted by
+// declination, the bounds based on the true obliquity for jdUt, clusters of (contra)parallel points and the
+// longitude equivalents. The result does not depend on the way it is drawn.
+// Positions should contain the index of the chartpoint, the longitude (Position1) and the declination (Position2)
+// PRE positions contains >= 1 items
+// PRE 0 < orb <= 10
+// PRE MinJdGeneral <= jdUt <= MaxJdGeneral
+// PRE for all longitudes in positions (Position1): 0.0 <= longitude < 360.0
+// PRE for all declinations in positions (Position2) : -180.0 < declination < 180.0
+// POST if no errors: returns the declination strip
+// POST if error(s): returns empty strip with error
+func (dss DeclStripService) DeclinationStrip(positions []domain.DoublePosition, jdUt float64, orb float64) (domain.DeclinationStrip, error) {
+	slog.Info("Started calculation of declination strip")
+	if len(positions) < 1 {
+		slog.Error("No positions found")
+		return domain.DeclinationStrip{}, errors.New("declination strip failed, not enough data")
+	}
+	if orb <= MinOrbDeclStrip || orb > MaxOrbDeclStrip {
+		slog.Error("Orb out of range")
+		return domain.DeclinationStrip{}, fmt.Errorf("orb %f is out of range, must be > %f and <= %f", orb, MinOrbDeclStrip, MaxOrbDeclStrip)
+	}
+	if jdUt < domain.MinJdGeneral || jdUt > domain.MaxJdGeneral {
+		slog.Error("Jd out of range")
+		return domain.DeclinationStrip{}, fmt.Errorf("jd %f is out of range", jdUt)
+	}
+	for _, pos := range positions {
+		if pos.Position1 < domain.MinLongitude || pos.Position1 >= domain.MaxLongitude {
+			slog.Error("Longitude out of range")
+			return domain.DeclinationStrip{}, fmt.Errorf("longitude %f is out of range", pos.Position1)
+		}
+		if pos.Position2 <= domain.MinDeclination || pos.Position2 >= domain.MaxDeclination {
+			slog.Error("Declination out of range")
+			return domain.DeclinationStrip{}, fmt.Errorf("declination %f is out of range", pos.Position2)
+		}
+	}
+	slog.Info("Completed calculation of declination strip")
+	return dss.dsCalc.CalcDeclStrip(positions, jdUt, orb)
+}
@@ -0,0 +1,47 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func TestDeclinationStripHappyFlow(t *testing.T) {
+	positions := []domain.DoublePosition{
+		{Id: domain.Sun, Position1: 90.0, Position2: 23.4},
+		{Id: domain.Moon, Position1: 100.0, Position2: -23.0},
+	}
+	dss := NewDeclStripService()
+	result, err := dss.DeclinationStrip(positions, 2_451_545.0, 1.0)
+	if err != nil {
+		t.Fatalf("DeclinationStrip returned unexpected error %v", err)
+	}
+	if len(result.Items) != 2 || result.Items[0].Point != domain.Sun || len(result.Clusters) != 1 {
+		t.Errorf("DeclinationStrip expected Sun first and one cluster, got %v", result)
+	}
+}
+
+func TestDeclinationStripNoPositions(t *testing.T) {
+	dss := NewDeclStripService()
+	_, err := dss.DeclinationStrip([]domain.DoublePosition{}, 2_451_545.0, 1.0)
+	if err == nil {
+		t.Errorf("Expected error for empty positions, but no error was returned")
+	}
+}
+
+func TestDeclinationStripLongitudeOutOfRange(t *testing.T) {
+	positions := []domain.DoublePosition{
+		{Id: domain.Sun, Position1: 360.0, Position2: 23.4},
+	}
+	dss := NewDeclStripService()
+	_, err := dss.DeclinationStrip(positions, 2_451_545.0, 1.0)
+	if err == nil {
+		t.Errorf("Expected error for longitude out of range, but no error was returned")
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/analysis"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// DignitiesServer provides services for the calculation of essential dignities and almutens.
+type DignitiesServer interface {
+	Dignities(positions []domain.SinglePosition,
+		diurnal bool,
+		triplicity domain.TriplicitySystem,
+		terms domain.TermsSystem) ([]domain.DignityScore, error)
+	Almuten(position float64,
+		diurnal bool,
+		triplicity domain.TriplicitySystem,
+		terms domain.TermsSystem) (domain.Almuten, error)
+	AlmutenFiguris(positions []float64,
+		diurnal bool,
+		triplicity domain.TriplicitySystem,
+		terms domain.TermsSystem) (domain.Almuten, error)
+}
+
+type DignitiesService struct {
+	dignCalc analysis.DignitiesCalculator
+}
+
+func NewDignitiesService() *DignitiesService {
+	dignCalculator := analysis.NewDignitiesCalculation()
+	return &DignitiesService{
+		dignCalc: dignCalculator,
+	}
+}
+
+const (
+	MinPosDignities = 0.0
+	MaxPosDignities = 360.0
+)
+
+// Dignities handles the calculation of the essential dignities and debilities of the classical planets.
+// PRE: length positions >= 1
+// PRE: all ids in positions are classical planets (Sun .. Saturn)
+// PRE: for all values for position in positions: 0.0 <= value < 360.0
+// PRE: triplicity and terms are known systems
+// POST: no errors -> returns the dignity scores in the same sequence as positions
+// POST: contains errors -> returns nil and error
+func (ds DignitiesService) Dignities(positions []domain.SinglePosition,
+	diurnal bool,
+	triplicity domain.TriplicitySystem,
+	terms domain.TermsSystem) ([]domain.DignityScore, error) {
+
+	slog.Info("Started calculation of dignities")
+	if len(positions) < MinPointsPerChart {
+		slog.Error("Not enough positions")
+		return nil, errors.New("dignities failed, not enough data")
+	}
+	for _, pos := range positions {
+		if pos.Id < domain.Sun || pos.Id > domain.Saturn {
+			slog.Error("Point is not a classical planet", "point", pos.Id)
+			return nil, fmt.Errorf("dignities failed, point %d is not a classical planet", pos.Id)
+		}
+		if err := validateDignityPosition(pos.Position); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateDignitySystems(triplicity, terms); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed calculation of dignities")
+	return ds.dignCalc.CalcDignities(positions, diurnal, triplicity, terms)
+}
+
+// Almuten handles the calculation of the almuten of a degree.
+// PRE: 0.0 <= position < 360.0
+// PRE: triplicity and terms are known systems
+// POST: no errors -> returns the almuten
+// POST: contains errors -> returns empty almuten and error
+func (ds DignitiesService) Almuten(position float64,
+	diurnal bool,
+	triplicity domain.TriplicitySystem,
+	terms domain.TermsSystem) (domain.Almuten, error) {
+
+	slog.Info("Started calculation of almuten")
+	if err := validateDignityPosition(position); err != nil {
+		return domain.Almuten{}, err
+	}
+	if err := validateDignitySystems(triplicity, terms); err != nil {
+		return domain.Almuten{}, err
+	}
+	slog.Info("Completed calculation of almuten")
+	return ds.dignCalc.CalcAlmuten(position, diurnal, triplicity, terms)
+}
+
+// AlmutenFiguris handles the calculation of the almuten for a set of positions, normally the Sun, the Moon, the
+// ascendant, the Part of Fortune and the prenatal syzygy.
+// PRE: length positions >= 1
+// PRE: for all values in positions: 0.0 <= value < 360.0
+// PRE: triplicity and terms are known systems
+// POST: no errors -> returns the almuten
+// POST: contains errors -> returns empty almuten and error
+func (ds DignitiesService) AlmutenFiguris(positions []float64,
+	diurnal bool,
+	triplicity domain.TriplicitySystem,
+	terms domain.TermsSystem) (domain.Almuten, error) {
+
+	slog.Info("Started calculation of almuten figuris")
+	if len(positions) < MinPointsPerChart {
+		slog.Error("Not enough positions")
+		return domain.Almuten{}, errors.New("almuten figuris failed, not enough data")
+	}
+	for _, position := range positions {
+		if err := validateDignityPosition(position); err != nil {
+			return domain.Almuten{}, err
+		}
+	}
+	if err := validateDignitySystems(triplicity, terms); err != nil {
+		return domain.Almuten{}, err
+	}
+	slog.Info("Completed calculation of almuten figuris")
+	return ds.dignCalc.CalcAlmutenFiguris(positions, diurnal, triplicity, terms)
+}
+
+func validateDignityPosition(position float64) error {
+	if position < MinPosDignities || position >= MaxPosDignities {
+		slog.Error("Position out of range")
+		return fmt.Errorf("position %f must be between 0.0 and <360.0", position)
+	}
+	return nil
+}
+
+func validateDignitySystems(triplicity domain.TriplicitySystem, terms domain.TermsSystem) error {
+	if triplicity < domain.TriplicityDorothean || triplicity > domain.TriplicityPtolemaic {
+		slog.Error("Unknown triplicity system", "triplicity", triplicity)
+		return fmt.Errorf("unknown triplicity system %d", triplicity)
+	}
+	if terms < domain.TermsEgyptian || terms > domain.TermsPtolemaic {
+		slog.Error("Unknown terms system", "terms", terms)
+		return fmt.Errorf("unknown terms system %d", terms)
+	}
+	return nil
+}
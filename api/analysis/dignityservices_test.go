@@ -0,0 +1,71 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func TestDignitiesNotAClassicalPlanet(t *testing.T) {
+	positions := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 135.0},
+		{Id: domain.Uranus, Position: 10.0},
+	}
+	dService := NewDignitiesService()
+	result, err := dService.Dignities(positions, true, domain.TriplicityLilly, domain.TermsEgyptian)
+	if err == nil {
+		t.Errorf("Expected error for a point that is not a classical planet, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for a point that is not a classical planet")
+	}
+}
+
+func TestDignitiesUnknownTriplicitySystem(t *testing.T) {
+	positions := []domain.SinglePosition{{Id: domain.Sun, Position: 135.0}}
+	dService := NewDignitiesService()
+	result, err := dService.Dignities(positions, true, domain.TriplicitySystem(7), domain.TermsEgyptian)
+	if err == nil {
+		t.Errorf("Expected error for an unknown triplicity system, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for an unknown triplicity system")
+	}
+}
+
+func TestDignitiesHappyFlow(t *testing.T) {
+	positions := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 135.0},
+		{Id: domain.Moon, Position: 33.0},
+	}
+	dService := NewDignitiesService()
+	result, err := dService.Dignities(positions, true, domain.TriplicityPtolemaic, domain.TermsPtolemaic)
+	if err != nil {
+		t.Fatalf("Dignities returned unexpected error %v", err)
+	}
+	if len(result) != 2 || result[1].Score != 4 {
+		t.Errorf("Expected exaltation for the Moon in Taurus, got %v", result)
+	}
+}
+
+func TestAlmutenPositionOutOfRange(t *testing.T) {
+	dService := NewDignitiesService()
+	_, err := dService.Almuten(360.0, true, domain.TriplicityLilly, domain.TermsEgyptian)
+	if err == nil {
+		t.Errorf("Expected error for a position that is out of range, but no error was returned")
+	}
+}
+
+func TestAlmutenFigurisNotEnoughData(t *testing.T) {
+	dService := NewDignitiesService()
+	_, err := dService.AlmutenFiguris([]float64{}, true, domain.TriplicityLilly, domain.TermsEgyptian)
+	if err == nil {
+		t.Errorf("Expected error for an empty set of positions, but no error was returned")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/analysis"
+	"fmt"
+	"log/slog"
+)
+
+// DispositorServer provides services for the calculation of dispositors, final dispositors and mutual receptions.
+type DispositorServer interface {
+	Dispositors(chart domain.FullChartResponse, system domain.RulershipSystem) (domain.DispositorGraph, error)
+}
+
+type DispositorService struct {
+	dispCalc analysis.DispositorsCalculator
+}
+
+func NewDispositorService() *DispositorService {
+	dispCalculator := analysis.NewDispositorsCalculation()
+	return &DispositorService{
+		dispCalc: dispCalculator,
+	}
+}
+
+// Dispositors handles the calculation of the dispositor graph, including final dispositors, loops and mutual
+// receptions. Only the planets are used, other points in the chart are ignored.
+// PRE: system is a known rulership system
+// PRE: chart contains Sun .. Saturn, and for the modern system also Uranus, Neptune and Pluto
+// PRE: for all points in chart: 0.0 <= LonPos < 360.0
+// POST: no errors -> returns the dispositor graph
+// POST: contains errors -> returns empty graph and error
+func (ds DispositorService) Dispositors(chart domain.FullChartResponse, system domain.RulershipSystem) (domain.DispositorGraph, error) {
+	slog.Info("Started calculation of dispositors")
+	if system < domain.RulershipTraditional || system > domain.RulershipModern {
+		slog.Error("Unknown rulership system", "system", system)
+		return domain.DispositorGraph{}, fmt.Errorf("unknown rulership system %d", system)
+	}
+	for _, point := range chart.Points {
+		if point.LonPos < 0.0 || point.LonPos >= 360.0 {
+			slog.Error("Longitude out of range")
+			return domain.DispositorGraph{}, fmt.Errorf("dispositors failed, longitude %f out of range", point.LonPos)
+		}
+	}
+	graph, err := ds.dispCalc.CalcDispositors(chart, system)
+	if err != nil {
+		slog.Error("Calculation of dispositors failed", "error", err)
+		return domain.DispositorGraph{}, err
+	}
+	slog.Info("Completed calculation of dispositors")
+	return graph, nil
+}
@@ -0,0 +1,49 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func createChartForDispositors(lonPositions []float64) domain.FullChartResponse {
+	points := make([]domain.PointPosResult, 0, len(lonPositions))
+	for i, lon := range lonPositions {
+		points = append(points, domain.PointPosResult{Point: domain.ChartPoint(i), LonPos: lon})
+	}
+	return domain.FullChartResponse{Points: points}
+}
+
+func TestDispositorsUnknownSystem(t *testing.T) {
+	chart := createChartForDispositors([]float64{135.0, 10.0, 140.0, 160.0, 100.0, 250.0, 40.0})
+	dService := NewDispositorService()
+	if _, err := dService.Dispositors(chart, domain.RulershipSystem(5)); err == nil {
+		t.Errorf("Expected error for an unknown rulership system, but no error was returned")
+	}
+}
+
+func TestDispositorsLongitudeOutOfRange(t *testing.T) {
+	chart := createChartForDispositors([]float64{135.0, 10.0, 140.0, 160.0, 100.0, 250.0, 360.0})
+	dService := NewDispositorService()
+	if _, err := dService.Dispositors(chart, domain.RulershipTraditional); err == nil {
+		t.Errorf("Expected error for a longitude out of range, but no error was returned")
+	}
+}
+
+func TestDispositorsHappyFlow(t *testing.T) {
+	chart := createChartForDispositors([]float64{135.0, 10.0, 140.0, 160.0, 100.0, 250.0, 40.0})
+	dService := NewDispositorService()
+	result, err := dService.Dispositors(chart, domain.RulershipTraditional)
+	if err != nil {
+		t.Fatalf("Dispositors returned unexpected error %v", err)
+	}
+	if len(result.Nodes) != 7 || len(result.FinalDispositors) != 2 || len(result.Loops) != 1 {
+		t.Errorf("Expected 7 nodes, 2 final dispositors and 1 loop, got %v", result)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/analysis"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// DistributionServer provides services for the distribution of a chart over elements, modalities, hemispheres and
+// quadrants, and for the chart shape.
+type DistributionServer interface {
+	Distribution(chart domain.FullChartResponse, cfgPoints []domain.ConfigPoint) (domain.ChartDistribution, error)
+}
+
+type DistributionService struct {
+	distrCalc analysis.DistributionCalculator
+}
+
+func NewDistributionService() *DistributionService {
+	distrCalculator := analysis.NewDistributionCalculation()
+	return &DistributionService{
+		distrCalc: distrCalculator,
+	}
+}
+
+const MinPlanetsForShape = 2
+
+// Distribution handles the calculation of the distribution and the shape of a chart. The weights of the points
+// are taken from cfgPoints.
+// PRE: chart contains at least 2 planets (Sun .. Pluto)
+// PRE: for all points and for the ascendant and MC: 0.0 <= LonPos < 360.0
+// PRE: for all weights in cfgPoints: weight >= 0.0
+// POST: no errors -> returns the distribution
+// POST: contains errors -> returns empty distribution and error
+func (ds DistributionService) Distribution(chart domain.FullChartResponse, cfgPoints []domain.ConfigPoint) (domain.ChartDistribution, error) {
+	slog.Info("Started calculation of chart distribution")
+	nrOfPlanets := 0
+	for _, point := range chart.Points {
+		if point.LonPos < 0.0 || point.LonPos >= 360.0 {
+			slog.Error("Longitude out of range")
+			return domain.ChartDistribution{}, fmt.Errorf("distribution failed, longitude %f out of range", point.LonPos)
+		}
+		if point.Point >= domain.Sun && point.Point <= domain.Pluto {
+			nrOfPlanets++
+		}
+	}
+	if nrOfPlanets < MinPlanetsForShape {
+		slog.Error("Not enough planets")
+		return domain.ChartDistribution{}, errors.New("distribution failed, not enough planets")
+	}
+	for _, angle := range []float64{chart.Asc.LonPos, chart.Mc.LonPos} {
+		if angle < 0.0 || angle >= 360.0 {
+			slog.Error("Angle out of range")
+			return domain.ChartDistribution{}, fmt.Errorf("distribution failed, angle %f out of range", angle)
+		}
+	}
+	for _, cfgPoint := range cfgPoints {
+		if cfgPoint.DistributionWeight < 0.0 {
+			slog.Error("Negative weight", "point", cfgPoint.ActualPoint)
+			return domain.ChartDistribution{}, fmt.Errorf("distribution failed, negative weight for point %d", cfgPoint.ActualPoint)
+		}
+	}
+	slog.Info("Completed calculation of chart distribution")
+	return ds.distrCalc.CalcDistribution(chart, cfgPoints)
+}
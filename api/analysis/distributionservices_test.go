@@ -0,0 +1,65 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/meta"
+	"testing"
+)
+
+func createChartForDistribution(lonPositions []float64) domain.FullChartResponse {
+	points := make([]domain.PointPosResult, 0, len(lonPositions))
+	for i, lon := range lonPositions {
+		points = append(points, domain.PointPosResult{Point: domain.ChartPoint(i), LonPos: lon})
+	}
+	return domain.FullChartResponse{
+		Points: points,
+		Asc:    domain.HousePosResult{LonPos: 45.0},
+		Mc:     domain.HousePosResult{LonPos: 315.0},
+	}
+}
+
+func TestDistributionNotEnoughPlanets(t *testing.T) {
+	chart := createChartForDistribution([]float64{10.0})
+	dService := NewDistributionService()
+	if _, err := dService.Distribution(chart, meta.DefaultConfig().Points); err == nil {
+		t.Errorf("Expected error for a single planet, but no error was returned")
+	}
+}
+
+func TestDistributionAngleOutOfRange(t *testing.T) {
+	chart := createChartForDistribution([]float64{10.0, 20.0, 30.0})
+	chart.Mc.LonPos = 360.0
+	dService := NewDistributionService()
+	if _, err := dService.Distribution(chart, meta.DefaultConfig().Points); err == nil {
+		t.Errorf("Expected error for an angle out of range, but no error was returned")
+	}
+}
+
+func TestDistributionNegativeWeight(t *testing.T) {
+	chart := createChartForDistribution([]float64{10.0, 20.0, 30.0})
+	cfgPoints := meta.DefaultConfig().Points
+	cfgPoints[0].DistributionWeight = -1.0
+	dService := NewDistributionService()
+	if _, err := dService.Distribution(chart, cfgPoints); err == nil {
+		t.Errorf("Expected error for a negative weight, but no error was returned")
+	}
+}
+
+func TestDistributionHappyFlow(t *testing.T) {
+	chart := createChartForDistribution([]float64{0, 36, 72, 108, 144, 180, 216, 252, 288, 324})
+	dService := NewDistributionService()
+	result, err := dService.Distribution(chart, meta.DefaultConfig().Points)
+	if err != nil {
+		t.Fatalf("Distribution returned unexpected error %v", err)
+	}
+	if result.Shape != domain.ShapeSplash {
+		t.Errorf("Expected a splash, got %v", result.Shape)
+	}
+}
@@ -18,6 +18,8 @@ import (
 // HarmonicServer provides services for the calculation of harmonics
 type HarmonicServer interface {
 	Harmonics(actPositions []domain.SinglePosition, harmonicNr float64) ([]domain.SinglePosition, error)
+	HarmonicChart(actPositions []domain.SinglePosition, harmonicNr float64, orb float64) (domain.HarmonicChart, error)
+	HarmonicConjunctions(actPositions []domain.SinglePosition, minHarmonic int, maxHarmonic int, orb float64) ([]domain.HarmonicConjunctions, error)
 }
 
 type HarmonicService struct {
@@ -31,6 +33,14 @@ func NewHarmonicService() *HarmonicService {
 	}
 }
 
+const (
+	MinHarmonic        = 1
+	MaxHarmonic        = 100_000
+	MinOrbForHarmonics = 0.0
+	MaxOrbForHarmonics = 20.0
+	MinItemsForHarmAsp = 2
+)
+
 // Harmonics handles the calculation of harmonics
 // PRE: 1 <= harmonicNr <= 1000
 // PRE: for all values for position in actPositions: 0.0 <= value < 360.0
@@ -38,10 +48,6 @@ func NewHarmonicService() *HarmonicService {
 // POST: no errors -> returns calculated harmonics
 // POST: contains errors -> returns nil and error
 func (hs HarmonicService) Harmonics(actPositions []domain.SinglePosition, harmonicNr float64) ([]domain.SinglePosition, error) {
-	const (
-		MinHarmonic = 1
-		MaxHarmonic = 100_000
-	)
 	slog.Info("Starting calculation of harmonics")
 
 	if harmonicNr < MinHarmonic || harmonicNr > MaxHarmonic {
@@ -61,3 +67,62 @@ func (hs HarmonicService) Harmonics(actPositions []domain.SinglePosition, harmon
 	slog.Info("Completed calculation of harmonics")
 	return hs.hrmCalc.CalcHarmonics(actPositions, harmonicNr)
 }
+
+// HarmonicChart handles the calculation of a harmonic chart with conjunctions and oppositions.
+// PRE: 1 <= harmonicNr <= 100000
+// PRE: 0.0 < orb <= 20.0
+// PRE: for all values for position in actPositions: 0.0 <= value < 360.0
+// PRE: length actPostions >= 2
+// POST: no errors -> returns harmonic chart
+// POST: contains errors -> returns empty harmonic chart and error
+func (hs HarmonicService) HarmonicChart(actPositions []domain.SinglePosition, harmonicNr float64, orb float64) (domain.HarmonicChart, error) {
+	slog.Info("Starting calculation of harmonic chart")
+	if harmonicNr < MinHarmonic || harmonicNr > MaxHarmonic {
+		slog.Error("Harmonic number out of range")
+		return domain.HarmonicChart{}, fmt.Errorf("harmonic chart failed, harmonicNr should be >= %d and <= %d, but was %f",
+			MinHarmonic, MaxHarmonic, harmonicNr)
+	}
+	if err := validateHarmonicAspectInput(actPositions, orb); err != nil {
+		return domain.HarmonicChart{}, err
+	}
+	slog.Info("Completed calculation of harmonic chart")
+	return hs.hrmCalc.CalcHarmonicChart(actPositions, harmonicNr, orb)
+}
+
+// HarmonicConjunctions handles the scan for conjunctions in the harmonics minHarmonic .. maxHarmonic.
+// PRE: 1 <= minHarmonic <= maxHarmonic <= 100000
+// PRE: 0.0 < orb <= 20.0
+// PRE: for all values for position in actPositions: 0.0 <= value < 360.0
+// PRE: length actPostions >= 2
+// POST: no errors -> returns the pairs of points that are conjunct in at least one harmonic
+// POST: contains errors -> returns nil and error
+func (hs HarmonicService) HarmonicConjunctions(actPositions []domain.SinglePosition, minHarmonic int, maxHarmonic int, orb float64) ([]domain.HarmonicConjunctions, error) {
+	slog.Info("Starting scan for harmonic conjunctions")
+	if minHarmonic < MinHarmonic || maxHarmonic > MaxHarmonic || minHarmonic > maxHarmonic {
+		slog.Error("Harmonic range out of range")
+		return nil, fmt.Errorf("harmonic conjunctions failed, invalid range of harmonics %d .. %d", minHarmonic, maxHarmonic)
+	}
+	if err := validateHarmonicAspectInput(actPositions, orb); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed scan for harmonic conjunctions")
+	return hs.hrmCalc.CalcHarmonicConjunctions(actPositions, minHarmonic, maxHarmonic, orb)
+}
+
+func validateHarmonicAspectInput(actPositions []domain.SinglePosition, orb float64) error {
+	if orb <= MinOrbForHarmonics || orb > MaxOrbForHarmonics {
+		slog.Error("Orb out of range")
+		return fmt.Errorf("orb should be > %f and <= %f, but was %f", MinOrbForHarmonics, MaxOrbForHarmonics, orb)
+	}
+	if len(actPositions) < MinItemsForHarmAsp {
+		slog.Error("not enough positions")
+		return errors.New("not enough positions")
+	}
+	for _, pos := range actPositions {
+		if pos.Position < 0.0 || pos.Position >= 360.0 {
+			slog.Error("position out of range")
+			return fmt.Errorf("encountered position %f, this is outside range: >= 0.0 and < 360.0", pos.Position)
+		}
+	}
+	return nil
+}
@@ -91,3 +91,59 @@ func TestHarmonicsPositionTooSmall(t *testing.T) {
 		t.Errorf("Harmonics should have returned nil for a position that is too small")
 	}
 }
+
+func TestHarmonicChartHappyFlow(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 1, Position: 10.0},
+		{Id: 2, Position: 82.0},
+	}
+	hService := NewHarmonicService()
+	result, err := hService.HarmonicChart(positions, 5.0, 2.0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result.Aspects) != 1 || !result.Aspects[0].Conjunction {
+		t.Errorf("Expected one conjunction, got %v", result.Aspects)
+	}
+}
+
+func TestHarmonicChartOrbTooLarge(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 1, Position: 10.0},
+		{Id: 2, Position: 82.0},
+	}
+	hService := NewHarmonicService()
+	_, err := hService.HarmonicChart(positions, 5.0, 20.5)
+	if err == nil {
+		t.Errorf("Expected error for orb that is too large, but no error was returned")
+	}
+}
+
+func TestHarmonicConjunctionsInvalidRange(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 1, Position: 10.0},
+		{Id: 2, Position: 82.0},
+	}
+	hService := NewHarmonicService()
+	result, err := hService.HarmonicConjunctions(positions, 32, 1, 2.0)
+	if err == nil {
+		t.Errorf("Expected error for invalid range of harmonics, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for invalid range of harmonics")
+	}
+}
+
+func TestHarmonicConjunctionsNotEnoughPositions(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 1, Position: 10.0},
+	}
+	hService := NewHarmonicService()
+	result, err := hService.HarmonicConjunctions(positions, 1, 32, 2.0)
+	if err == nil {
+		t.Errorf("Expected error for not enough positions, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for not enough positions")
+	}
+}
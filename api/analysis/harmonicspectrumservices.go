@@ -0,0 +1,65 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/analysis"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// HarmonicSpectrumServer provides services for the calculation of a harmonic spectrum.
+type HarmonicSpectrumServer interface {
+	HarmonicSpectrum(positions []float64, maxHarmonic int) ([]domain.HarmonicComponent, error)
+}
+
+type HarmonicSpectrumService struct {
+	spcCalc analysis.HarmonicSpectrumCalculator
+}
+
+func NewHarmonicSpectrumService() *HarmonicSpectrumService {
+	spcCalculator := analysis.NewHarmonicSpectrumCalculation()
+	return &HarmonicSpectrumService{
+		spcCalc: spcCalculator,
+	}
+}
+
+const (
+	MinPositionsForSpectrum = 2
+	MinHarmonicForSpectrum  = 1
+	MaxHarmonicForSpectrum  = 1000
+)
+
+// HarmonicSpectrum handles the calculation of amplitude, phase and significance for the harmonics 1 .. maxHarmonic.
+// PRE: length positions >= 2
+// PRE: 1 <= maxHarmonic <= 1000
+// PRE: for all positions: 0.0 <= position < 360.0
+// POST: no errors -> returns a component for each harmonic
+// POST: contains errors -> returns nil and error
+func (hss HarmonicSpectrumService) HarmonicSpectrum(positions []float64, maxHarmonic int) ([]domain.HarmonicComponent, error) {
+	slog.Info("Starting calculation of harmonic spectrum")
+	if len(positions) < MinPositionsForSpectrum {
+		slog.Error("not enough positions")
+		return nil, errors.New("harmonic spectrum failed, not enough positions")
+	}
+	if maxHarmonic < MinHarmonicForSpectrum || maxHarmonic > MaxHarmonicForSpectrum {
+		slog.Error("Harmonic number out of range")
+		return nil, fmt.Errorf("harmonic spectrum failed, maxHarmonic should be >= %d and <= %d, but was %d",
+			MinHarmonicForSpectrum, MaxHarmonicForSpectrum, maxHarmonic)
+	}
+	for _, pos := range positions {
+		if pos < domain.MinLongitude || pos >= domain.MaxLongitude {
+			slog.Error("position out of range")
+			return nil, fmt.Errorf("harmonic spectrum failed, encountered position %f, this is outside range: >= 0.0 and < 360.0", pos)
+		}
+	}
+	slog.Info("Completed calculation of harmonic spectrum")
+	return hss.spcCalc.CalcSpectrum(positions, maxHarmonic)
+}
@@ -0,0 +1,56 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"testing"
+)
+
+func TestHarmonicSpectrumHappyFlow(t *testing.T) {
+	hss := NewHarmonicSpectrumService()
+	result, err := hss.HarmonicSpectrum([]float64{10.0, 100.0, 190.0, 280.0}, 12)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(result) != 12 {
+		t.Errorf("Expected 12 components, got %d", len(result))
+	}
+}
+
+func TestHarmonicSpectrumNotEnoughPositions(t *testing.T) {
+	hss := NewHarmonicSpectrumService()
+	result, err := hss.HarmonicSpectrum([]float64{10.0}, 12)
+	if err == nil {
+		t.Errorf("Expected error for not enough positions, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for not enough positions")
+	}
+}
+
+func TestHarmonicSpectrumMaxHarmonicTooLarge(t *testing.T) {
+	hss := NewHarmonicSpectrumService()
+	result, err := hss.HarmonicSpectrum([]float64{10.0, 20.0}, 1001)
+	if err == nil {
+		t.Errorf("Expected error for maxHarmonic that is too large, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for maxHarmonic that is too large")
+	}
+}
+
+func TestHarmonicSpectrumPositionOutOfRange(t *testing.T) {
+	hss := NewHarmonicSpectrumService()
+	result, err := hss.HarmonicSpectrum([]float64{10.0, 360.0}, 12)
+	if err == nil {
+		t.Errorf("Expected error for position out of range, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for position out of range")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"enigma-ar/domain"
 	"enigma-ar/internal/analysis"
 	"errors"
+	"fmt"
 	"log/slog"
 )
 
@@ -18,6 +19,11 @@ import (
 type MidpointsServer interface {
 	Midpoints(points []domain.SinglePosition) ([]domain.Midpoint, error)
 	OccupiedMidpoints(points []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.OccupiedMidpoint, error)
+	InterMidpoints(pointsA []domain.SinglePosition, pointsB []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.OccupiedMidpoint, error)
+	PlanetaryPictures(points []domain.SinglePosition, pictureType domain.PlanetaryPictureType, dial domain.MpDial, orb float64) ([]domain.PlanetaryPicture, error)
+	MidpointTrees(points []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.MidpointTree, error)
+	DialSort(points []domain.SinglePosition, dial domain.MpDial) ([]domain.DialSortItem, error)
+	FramedMidpoints(points []domain.PointPosResult, frame domain.CoordinateSystem, dial domain.MpDial, orbs domain.ConfigOrbs) ([]domain.FramedMidpoint, error)
 }
 
 type MidpointService struct {
@@ -38,6 +44,7 @@ const (
 	MaxOrbForMP       = 10.0
 	MinPosForMP       = 0.0
 	MaxPosForMP       = 360.0
+	MinItemsForPic    = 4
 )
 
 // Midpoints handles the calculation of midpoints.
@@ -86,3 +93,178 @@ func (mps MidpointService) OccupiedMidpoints(points []domain.SinglePosition, dia
 	slog.Info("Completed calculation of occupied midpoints")
 	return mps.mpCalc.CalcOccupiedMidpoints(points, dial, orb)
 }
+
+// InterMidpoints handles the calculation of midpoints in chart B that are occupied by points of chart A.
+// PRE length pointsA >= 1
+// PRE length pointsB >= 2
+// PRE for all positions : 0.0 <= position < 360.0
+// PRE 0.0 < orb <= 10.0
+// POST no errors -> returns slice of occupied midpoints
+// POST errors: returns nil and error
+func (mps MidpointService) InterMidpoints(pointsA []domain.SinglePosition, pointsB []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.OccupiedMidpoint, error) {
+
+	slog.Info("Started calculation of inter-midpoints")
+	if len(pointsA) < MinPointsPerChart || len(pointsB) < MinItemsForMP {
+		slog.Error("Not enough points")
+		return nil, errors.New("not enough points")
+	}
+	if orb <= MinOrbForMP || orb > MaxOrbForMP {
+		slog.Error("Orb out of range")
+		return nil, errors.New("orb must be between 0.0 and 10.0")
+	}
+	for _, points := range [][]domain.SinglePosition{pointsA, pointsB} {
+		for i := 0; i < len(points); i++ {
+			if points[i].Position < MinPosForMP || points[i].Position >= MaxPosForMP {
+				slog.Error("position out of range")
+				return nil, errors.New("positions must be between 0.0 and <360.0")
+			}
+		}
+	}
+	slog.Info("Completed calculation of inter-midpoints")
+	return mps.mpCalc.CalcInterMidpoints(pointsA, pointsB, dial, orb)
+}
+
+// PlanetaryPictures handles the calculation of planetary pictures, in the form A+B-C = D or A+B = C+D.
+// PRE length points >= 4
+// PRE pictureType is PictureSum or PictureBalance
+// PRE dial is one of the dials in domain.AllMpDials
+// PRE for all positions : 0.0 <= position < 360.0
+// PRE 0.0 < orb <= 10.0
+// POST no errors -> returns planetary pictures, sorted by exactness
+// POST errors: returns nil and error
+func (mps MidpointService) PlanetaryPictures(points []domain.SinglePosition, pictureType domain.PlanetaryPictureType, dial domain.MpDial, orb float64) ([]domain.PlanetaryPicture, error) {
+
+	slog.Info("Started calculation of planetary pictures")
+	if len(points) < MinItemsForPic {
+		slog.Error("Not enough points")
+		return nil, errors.New("not enough points")
+	}
+	if pictureType != domain.PictureSum && pictureType != domain.PictureBalance {
+		slog.Error("Unknown type of planetary picture")
+		return nil, errors.New("unknown type of planetary picture")
+	}
+	if int(dial) < 0 || int(dial) >= len(domain.AllMpDials()) {
+		slog.Error("Unknown dial")
+		return nil, errors.New("unknown dial")
+	}
+	if orb <= MinOrbForMP || orb > MaxOrbForMP {
+		slog.Error("Orb out of range")
+		return nil, errors.New("orb must be between 0.0 and 10.0")
+	}
+	for i := 0; i < len(points); i++ {
+		if points[i].Position < MinPosForMP || points[i].Position >= MaxPosForMP {
+			slog.Error("position out of range")
+			return nil, errors.New("positions must be between 0.0 and <360.0")
+		}
+	}
+	slog.Info("Completed calculation of planetary pictures")
+	return mps.mpCalc.CalcPlanetaryPictures(points, pictureType, dial, orb)
+}
+
+// MidpointTrees handles the calculation of midpoint trees, for each point the occupied midpoints sorted by orb.
+// PRE length points >= 3
+// PRE dial is one of the dials in domain.AllMpDials
+// PRE for all positions : 0.0 <= position < 360.0
+// PRE 0.0 < orb <= 10.0
+// POST no errors -> returns a midpoint tree for each point
+// POST errors: returns nil and error
+func (mps MidpointService) MidpointTrees(points []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.MidpointTree, error) {
+
+	slog.Info("Started calculation of midpoint trees")
+	if len(points) < MinItemsForCalcMP {
+		slog.Error("Not enough points")
+		return nil, errors.New("not enough points")
+	}
+	if int(dial) < 0 || int(dial) >= len(domain.AllMpDials()) {
+		slog.Error("Unknown dial")
+		return nil, errors.New("unknown dial")
+	}
+	if orb <= MinOrbForMP || orb > MaxOrbForMP {
+		slog.Error("Orb out of range")
+		return nil, errors.New("orb must be between 0.0 and 10.0")
+	}
+	for i := 0; i < len(points); i++ {
+		if points[i].Position < MinPosForMP || points[i].Position >= MaxPosForMP {
+			slog.Error("position out of range")
+			return nil, errors.New("positions must be between 0.0 and <360.0")
+		}
+	}
+	slog.Info("Completed calculation of midpoint trees")
+	return mps.mpCalc.CalcMidpointTrees(points, dial, orb)
+}
+
+// DialSort handles the sorting of all points and midpoints in the sequence of a dial, typically the 90 degree dial.
+// PRE length points >= 2
+// PRE dial is one of the dials in domain.AllMpDials
+// PRE for all positions : 0.0 <= position < 360.0
+// POST no errors -> returns all points and midpoints, sorted by position in the dial
+// POST errors: returns nil and error
+func (mps MidpointService) DialSort(points []domain.SinglePosition, dial domain.MpDial) ([]domain.DialSortItem, error) {
+
+	slog.Info("Started dial sort")
+	if len(points) < MinItemsForMP {
+		slog.Error("Not enough points")
+		return nil, errors.New("not enough points")
+	}
+	if int(dial) < 0 || int(dial) >= len(domain.AllMpDials()) {
+		slog.Error("Unknown dial")
+		return nil, errors.New("unknown dial")
+	}
+	for i := 0; i < len(points); i++ {
+		if points[i].Position < MinPosForMP || points[i].Position >= MaxPosForMP {
+			slog.Error("position out of range")
+			return nil, errors.New("positions must be between 0.0 and <360.0")
+		}
+	}
+	slog.Info("Completed dial sort")
+	return mps.mpCalc.CalcDialSort(points, dial)
+}
+
+// FramedMidpoints handles the calculation of occupied midpoints in longitude, right ascension or azimuth.
+// The orb is taken from orbs: BaseOrbMidpoints for longitude, OrbRaMidpoints for right ascension and
+// OrbAzimuthMidpoints for azimuth. For midpoints in declination use DeclinationMidpointService.
+// PRE length points >= 3
+// PRE frame is CoordEcliptical, CoordEquatorial or CoordHorizontal
+// PRE for all positions in the frame: 0.0 <= position < 360.0
+// PRE 0.0 < orb for the frame <= 10.0
+// POST no errors -> returns slice of framed midpoints
+// POST errors: returns nil and error
+func (mps MidpointService) FramedMidpoints(points []domain.PointPosResult, frame domain.CoordinateSystem, dial domain.MpDial, orbs domain.ConfigOrbs) ([]domain.FramedMidpoint, error) {
+
+	slog.Info("Started calculation of framed midpoints")
+	var orb float64
+	switch frame {
+	case domain.CoordEcliptical:
+		orb = orbs.BaseOrbMidpoints
+	case domain.CoordEquatorial:
+		orb = orbs.OrbRaMidpoints
+	case domain.CoordHorizontal:
+		orb = orbs.OrbAzimuthMidpoints
+	default:
+		slog.Error("Frame not supported for midpoints", "frame", frame)
+		return nil, fmt.Errorf("frame %d is not supported for midpoints", frame)
+	}
+	if len(points) < MinItemsForCalcMP {
+		slog.Error("Not enough points")
+		return nil, errors.New("not enough points")
+	}
+	if orb <= MinOrbForMP || orb > MaxOrbForMP {
+		slog.Error("Orb out of range")
+		return nil, errors.New("orb must be between 0.0 and 10.0")
+	}
+	for _, point := range points {
+		pos := point.LonPos
+		switch frame {
+		case domain.CoordEquatorial:
+			pos = point.RaPos
+		case domain.CoordHorizontal:
+			pos = point.AzimPos
+		}
+		if pos < MinPosForMP || pos >= MaxPosForMP {
+			slog.Error("position out of range")
+			return nil, errors.New("positions must be between 0.0 and <360.0")
+		}
+	}
+	slog.Info("Completed calculation of framed midpoints")
+	return mps.mpCalc.CalcFramedMidpoints(points, frame, dial, orb)
+}
@@ -9,6 +9,7 @@ package apianalysis
 
 import (
 	"enigma-ar/domain"
+	"enigma-ar/internal/meta"
 	"testing"
 )
 
@@ -149,3 +150,180 @@ func TestOccupiedMidpointOrbTooLarge(t *testing.T) {
 		t.Errorf("OccupiedMidpoints should have returned a nil for an orb that is too large")
 	}
 }
+
+func TestInterMidpointsNotEnoughPointsInChartB(t *testing.T) {
+	var positionsA = []domain.SinglePosition{
+		{Id: 2, Position: 12.0},
+	}
+	var positionsB = []domain.SinglePosition{
+		{Id: 3, Position: 40.0},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.InterMidpoints(positionsA, positionsB, domain.Dial360, 1.0)
+	if err == nil {
+		t.Errorf("InterMidpoints should have returned an error for not enough points in chart B")
+	}
+	if result != nil {
+		t.Errorf("InterMidpoints should have returned nil for not enough points in chart B")
+	}
+}
+
+func TestInterMidpointsPositionTooLarge(t *testing.T) {
+	var positionsA = []domain.SinglePosition{
+		{Id: 2, Position: 360.0},
+	}
+	var positionsB = []domain.SinglePosition{
+		{Id: 3, Position: 40.0},
+		{Id: 5, Position: 220.5},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.InterMidpoints(positionsA, positionsB, domain.Dial360, 1.0)
+	if err == nil {
+		t.Errorf("InterMidpoints should have returned an error for a position that is too large")
+	}
+	if result != nil {
+		t.Errorf("InterMidpoints should have returned nil for a position that is too large")
+	}
+}
+
+func TestPlanetaryPicturesNotEnoughPoints(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 2, Position: 12.0},
+		{Id: 3, Position: 40.0},
+		{Id: 5, Position: 220.5},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.PlanetaryPictures(positions, domain.PictureSum, domain.Dial90, 1.0)
+	if err == nil {
+		t.Errorf("PlanetaryPictures should have returned an error for not enough points")
+	}
+	if result != nil {
+		t.Errorf("PlanetaryPictures should have returned nil for not enough points")
+	}
+}
+
+func TestPlanetaryPicturesUnknownDial(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 2, Position: 12.0},
+		{Id: 3, Position: 40.0},
+		{Id: 5, Position: 220.5},
+		{Id: 40, Position: 100.5},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.PlanetaryPictures(positions, domain.PictureSum, domain.MpDial(8), 1.0)
+	if err == nil {
+		t.Errorf("PlanetaryPictures should have returned an error for an unknown dial")
+	}
+	if result != nil {
+		t.Errorf("PlanetaryPictures should have returned nil for an unknown dial")
+	}
+}
+
+func TestPlanetaryPicturesOrbTooLarge(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 2, Position: 12.0},
+		{Id: 3, Position: 40.0},
+		{Id: 5, Position: 220.5},
+		{Id: 40, Position: 100.5},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.PlanetaryPictures(positions, domain.PictureBalance, domain.Dial225, 10.5)
+	if err == nil {
+		t.Errorf("PlanetaryPictures should have returned an error for an orb that is too large")
+	}
+	if result != nil {
+		t.Errorf("PlanetaryPictures should have returned nil for an orb that is too large")
+	}
+}
+
+func TestMidpointTreesNotEnoughPoints(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 2, Position: 12.0},
+		{Id: 3, Position: 40.0},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.MidpointTrees(positions, domain.Dial90, 1.0)
+	if err == nil {
+		t.Errorf("MidpointTrees should have returned an error for not enough points")
+	}
+	if result != nil {
+		t.Errorf("MidpointTrees should have returned nil for not enough points")
+	}
+}
+
+func TestMidpointTreesHappyFlow(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 2, Position: 12.0},
+		{Id: 3, Position: 40.0},
+		{Id: 5, Position: 116.5},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.MidpointTrees(positions, domain.Dial90, 1.0)
+	if err != nil {
+		t.Fatalf("MidpointTrees returned unexpected error %v", err)
+	}
+	if len(result) != 3 || len(result[2].Midpoints) != 1 {
+		t.Errorf("MidpointTrees expected 3 trees and one midpoint for the last point, got %v", result)
+	}
+}
+
+func TestDialSortPositionTooLarge(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 2, Position: 12.0},
+		{Id: 3, Position: 360.0},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.DialSort(positions, domain.Dial90)
+	if err == nil {
+		t.Errorf("DialSort should have returned an error for a position that is too large")
+	}
+	if result != nil {
+		t.Errorf("DialSort should have returned nil for a position that is too large")
+	}
+}
+
+func TestFramedMidpointsUnsupportedFrame(t *testing.T) {
+	points := []domain.PointPosResult{
+		{Point: domain.Sun}, {Point: domain.Moon}, {Point: domain.Mercury},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.FramedMidpoints(points, domain.CoordinateSystem(9), domain.Dial360, meta.DefaultConfig().Orbs)
+	if err == nil {
+		t.Errorf("FramedMidpoints should have returned an error for an unsupported frame")
+	}
+	if result != nil {
+		t.Errorf("FramedMidpoints should have returned nil for an unsupported frame")
+	}
+}
+
+func TestFramedMidpointsAzimuthOrbOutOfRange(t *testing.T) {
+	points := []domain.PointPosResult{
+		{Point: domain.Sun}, {Point: domain.Moon}, {Point: domain.Mercury},
+	}
+	orbs := meta.DefaultConfig().Orbs
+	orbs.OrbAzimuthMidpoints = 0.0
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.FramedMidpoints(points, domain.CoordHorizontal, domain.Dial360, orbs)
+	if err == nil {
+		t.Errorf("FramedMidpoints should have returned an error for an azimuth orb that is out of range")
+	}
+	if result != nil {
+		t.Errorf("FramedMidpoints should have returned nil for an azimuth orb that is out of range")
+	}
+}
+
+func TestFramedMidpointsAzimuthHappyFlow(t *testing.T) {
+	points := []domain.PointPosResult{
+		{Point: domain.Sun, LonPos: 10.0, AzimPos: 100.0},
+		{Point: domain.Moon, LonPos: 50.0, AzimPos: 140.0},
+		{Point: domain.Mercury, LonPos: 200.0, AzimPos: 120.2},
+	}
+	mpCalc := NewMidpointService()
+	result, err := mpCalc.FramedMidpoints(points, domain.CoordHorizontal, domain.Dial360, meta.DefaultConfig().Orbs)
+	if err != nil {
+		t.Fatalf("FramedMidpoints returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Frame != domain.CoordHorizontal {
+		t.Errorf("FramedMidpoints expected one midpoint in azimuth, got %v", result)
+	}
+}
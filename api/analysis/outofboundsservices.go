@@ -0,0 +1,96 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/analysis"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// OutOfBoundsServer provides services for out-of-bounds declinations and parallels that take these into account.
+type OutOfBoundsServer interface {
+	OutOfBounds(declinations []domain.SinglePosition, jdUt float64) ([]domain.OobPosition, error)
+	OobParallels(declinations []domain.SinglePosition, jdUt float64, orb float64) ([]domain.MatchedParallel, error)
+}
+
+type OutOfBoundsService struct {
+	oobCalc analysis.OutOfBoundsCalculator
+}
+
+func NewOutOfBoundsService() *OutOfBoundsService {
+	oobCalculator := analysis.NewOutOfBoundsCalculation()
+	return &OutOfBoundsService{
+		oobCalc: oobCalculator,
+	}
+}
+
+const (
+	MinOrbOobParallels = 0.0
+	MaxOrbOobParallels = 10.0
+)
+
+// OutOfBounds handles the check for out-of-bounds declinations, using the true obliquity for jdUt.
+// PRE: length declinations >= 1
+// PRE: MinJdGeneral <= jdUt <= MaxJdGeneral
+// PRE: for all values for position in declinations: -180.0 < value < 180.0
+// POST: no errors -> returns the declinations with out-of-bounds info
+// POST: contains errors -> returns nil and error
+func (obs OutOfBoundsService) OutOfBounds(declinations []domain.SinglePosition, jdUt float64) ([]domain.OobPosition, error) {
+	slog.Info("Started check for out-of-bounds")
+	if len(declinations) < 1 {
+		slog.Error("Not enough positions")
+		return nil, errors.New("out-of-bounds failed, not enough data")
+	}
+	if err := validateOobInput(declinations, jdUt); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed check for out-of-bounds")
+	return obs.oobCalc.CalcOutOfBounds(declinations, jdUt)
+}
+
+// OobParallels handles the calculation of parallels and contra parallels, out-of-bounds declinations are mirrored
+// into the bounds, using the true obliquity for jdUt.
+// PRE: length declinations >= 2
+// PRE: 0 < orb <= 10
+// PRE: MinJdGeneral <= jdUt <= MaxJdGeneral
+// PRE: for all values for position in declinations: -180.0 < value < 180.0
+// POST: no errors -> returns calculated parallels and contra parallels with the original declinations
+// POST: contains errors -> returns nil and error
+func (obs OutOfBoundsService) OobParallels(declinations []domain.SinglePosition, jdUt float64, orb float64) ([]domain.MatchedParallel, error) {
+	slog.Info("Started calculation of oob parallels")
+	if len(declinations) < 2 {
+		slog.Error("Not enough positions")
+		return nil, errors.New("oob parallels failed, not enough data")
+	}
+	if orb <= MinOrbOobParallels || orb > MaxOrbOobParallels {
+		slog.Error("Orb out of range")
+		return nil, fmt.Errorf("orb %f is out of range, must be > %f and <= %f", orb, MinOrbOobParallels, MaxOrbOobParallels)
+	}
+	if err := validateOobInput(declinations, jdUt); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed calculation of oob parallels")
+	return obs.oobCalc.CalcOobParallels(declinations, jdUt, orb)
+}
+
+func validateOobInput(declinations []domain.SinglePosition, jdUt float64) error {
+	if jdUt < domain.MinJdGeneral || jdUt > domain.MaxJdGeneral {
+		slog.Error("Jd out of range")
+		return fmt.Errorf("jd %f is out of range", jdUt)
+	}
+	for _, decl := range declinations {
+		if decl.Position <= domain.MinDeclination || decl.Position >= domain.MaxDeclination {
+			slog.Error("Declination out of range")
+			return fmt.Errorf("declination %f is out of range", decl.Position)
+		}
+	}
+	return nil
+}
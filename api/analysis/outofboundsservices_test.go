@@ -0,0 +1,72 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func TestOutOfBoundsHappyFlow(t *testing.T) {
+	declinations := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 20.0},
+		{Id: domain.Moon, Position: 27.0},
+	}
+	obs := NewOutOfBoundsService()
+	result, err := obs.OutOfBounds(declinations, 2_451_545.0)
+	if err != nil {
+		t.Fatalf("OutOfBounds returned unexpected error %v", err)
+	}
+	if len(result) != 2 || result[0].OutOfBounds || !result[1].OutOfBounds {
+		t.Errorf("OutOfBounds expected only the Moon to be out of bounds, got %v", result)
+	}
+}
+
+func TestOutOfBoundsJdOutOfRange(t *testing.T) {
+	declinations := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 20.0},
+	}
+	obs := NewOutOfBoundsService()
+	result, err := obs.OutOfBounds(declinations, domain.MaxJdGeneral+1.0)
+	if err == nil {
+		t.Errorf("Expected error for jd out of range, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for jd out of range")
+	}
+}
+
+func TestOobParallelsHappyFlow(t *testing.T) {
+	declinations := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 22.0},
+		{Id: domain.Moon, Position: 24.9},
+	}
+	obs := NewOutOfBoundsService()
+	result, err := obs.OobParallels(declinations, 2_451_545.0, 1.0)
+	if err != nil {
+		t.Fatalf("OobParallels returned unexpected error %v", err)
+	}
+	if len(result) != 1 {
+		t.Errorf("OobParallels expected 1 parallel, got %v", result)
+	}
+}
+
+func TestOobParallelsOrbTooLarge(t *testing.T) {
+	declinations := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 22.0},
+		{Id: domain.Moon, Position: 24.9},
+	}
+	obs := NewOutOfBoundsService()
+	result, err := obs.OobParallels(declinations, 2_451_545.0, 11.0)
+	if err == nil {
+		t.Errorf("Expected error for orb that was too large, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for orb that was too large")
+	}
+}
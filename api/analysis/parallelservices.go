@@ -18,6 +18,8 @@ import (
 // ParallelServer provides services for the calculation of parallels and contraparallels
 type ParallelServer interface {
 	Parallels(actPositions []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error)
+	InterParallels(positionsA []domain.SinglePosition, positionsB []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error)
+	LatitudeParallels(latitudes []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error)
 }
 
 type ParallelService struct {
@@ -61,3 +63,60 @@ func (ps ParallelService) Parallels(actPositions []domain.SinglePosition, orb fl
 	slog.Info("Completed calculation of parallels")
 	return ps.parCalc.CalcParallels(actPositions, orb)
 }
+
+// InterParallels handles the calculation of parallels and contra parallels between two charts.
+// PRE: length positionsA >= 1
+// PRE: length positionsB >= 1
+// PRE: 0 < orb < 10
+// PRE: for all values for position in positionsA and positionsB: -180.0 < value < 180.0
+// POST: no errors -> returns calculated parallels and contra parallels, Pos1 is from chart A and Pos2 from chart B
+// POST: contains errors -> returns nil and error
+func (ps ParallelService) InterParallels(positionsA []domain.SinglePosition, positionsB []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error) {
+
+	const MaxDecl = 180.0
+	slog.Info("Started calculation of inter-parallels")
+	if len(positionsA) < MinPointsPerChart || len(positionsB) < MinPointsPerChart {
+		slog.Error("Not enough positions")
+		return nil, errors.New("inter-parallels failed, not enough data")
+	}
+	if orb <= 0.0 || orb >= 10.0 {
+		return nil, errors.New("inter-parallels failed, orb not > 0.0 or not <= 10.0")
+	}
+	for _, positions := range [][]domain.SinglePosition{positionsA, positionsB} {
+		for _, pos := range positions {
+			if math.Abs(pos.Position) >= MaxDecl {
+				slog.Error("Declination out of range")
+				return nil, errors.New("inter-parallels failed, found declination >= 180.0")
+			}
+		}
+	}
+	slog.Info("Completed calculation of inter-parallels")
+	return ps.parCalc.CalcInterParallels(positionsA, positionsB, orb)
+}
+
+// LatitudeParallels handles the calculation of parallels and contra parallels of celestial latitude.
+// PRE: length latitudes >= 2
+// PRE: 0 < orb < 10
+// PRE: for all values for position in latitudes: -90.0 <= value <= 90.0
+// POST: no errors -> returns calculated parallels and contra parallels
+// POST: contains errors -> returns nil and error
+func (ps ParallelService) LatitudeParallels(latitudes []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error) {
+
+	const MaxLat = 90.0
+	slog.Info("Started calculation of latitude parallels")
+	if len(latitudes) < 2 {
+		slog.Error("Not enough positions")
+		return nil, errors.New("latitude parallels failed, not enough data")
+	}
+	if orb <= 0.0 || orb >= 10.0 {
+		return nil, errors.New("latitude parallels failed, orb not > 0.0 or not <= 10.0")
+	}
+	for _, lat := range latitudes {
+		if math.Abs(lat.Position) > MaxLat {
+			slog.Error("Latitude out of range")
+			return nil, errors.New("latitude parallels failed, found latitude > 90.0")
+		}
+	}
+	slog.Info("Completed calculation of latitude parallels")
+	return ps.parCalc.CalcLatitudeParallels(latitudes, orb)
+}
@@ -83,3 +83,64 @@ func TestParallelsDataOutOfRange(t *testing.T) {
 		t.Errorf("Expected nil for declination that was too large")
 	}
 }
+
+func TestInterParallelsEmptyChart(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: 1, Position: 12.0},
+	}
+	pService := NewParallelService()
+	result, err := pService.InterParallels(positions, []domain.SinglePosition{}, 1.0)
+	if err == nil {
+		t.Errorf("Expected error for an empty chart, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for an empty chart")
+	}
+}
+
+func TestInterParallelsDeclinationTooLarge(t *testing.T) {
+	var positionsA = []domain.SinglePosition{
+		{Id: 1, Position: 12.0},
+	}
+	var positionsB = []domain.SinglePosition{
+		{Id: 1, Position: -180.0},
+	}
+	pService := NewParallelService()
+	result, err := pService.InterParallels(positionsA, positionsB, 1.0)
+	if err == nil {
+		t.Errorf("Expected error for a declination that was too large, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for a declination that was too large")
+	}
+}
+
+func TestLatitudeParallelsLatitudeOutOfRange(t *testing.T) {
+	var latitudes = []domain.SinglePosition{
+		{Id: 1, Position: 5.0},
+		{Id: 9, Position: 95.0},
+	}
+	pService := NewParallelService()
+	result, err := pService.LatitudeParallels(latitudes, 1.0)
+	if err == nil {
+		t.Errorf("Expected error for latitude out of range, but no error was returned")
+	}
+	if result != nil {
+		t.Errorf("Expected nil for latitude out of range")
+	}
+}
+
+func TestLatitudeParallelsHappyFlow(t *testing.T) {
+	var latitudes = []domain.SinglePosition{
+		{Id: 1, Position: 5.0},
+		{Id: 9, Position: 5.4},
+	}
+	pService := NewParallelService()
+	result, err := pService.LatitudeParallels(latitudes, 1.0)
+	if err != nil {
+		t.Fatalf("LatitudeParallels returned unexpected error %v", err)
+	}
+	if len(result) != 1 || !result[0].Parallel {
+		t.Errorf("Expected one parallel, got %v", result)
+	}
+}
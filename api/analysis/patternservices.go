@@ -0,0 +1,92 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/analysis"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// AspectPatternServer provides services for the recognition of aspect patterns and stelliums.
+type AspectPatternServer interface {
+	AspectPatterns(aspects []domain.ActualAspect,
+		points []domain.SinglePosition,
+		cusps []float64,
+		minStellium int) ([]domain.AspectPattern, error)
+}
+
+type AspectPatternService struct {
+	patCalc analysis.AspectPatternCalculator
+}
+
+func NewAspectPatternService() *AspectPatternService {
+	patCalculator := analysis.NewAspectPatternCalculation()
+	return &AspectPatternService{
+		patCalc: patCalculator,
+	}
+}
+
+const (
+	MinPointsForPatterns = 3
+	MinStellium          = 3
+	MinCuspsForPatterns  = 2
+)
+
+// AspectPatterns handles the recognition of aspect patterns, the aspects are typically the result of Aspects.
+// Cusps are optional, if no cusps are given no stelliums by house are calculated.
+// PRE length points >= 3
+// PRE minStellium >= 3
+// PRE length cusps == 0 or length cusps >= 2
+// PRE for all positions and cusps : 0.0 <= value < 360.0
+// PRE all points in aspects are also in points
+// POST no errors -> returns found patterns
+// POST errors: returns nil and error
+func (aps AspectPatternService) AspectPatterns(aspects []domain.ActualAspect,
+	points []domain.SinglePosition,
+	cusps []float64,
+	minStellium int) ([]domain.AspectPattern, error) {
+	slog.Info("Started recognition of aspect patterns")
+	if len(points) < MinPointsForPatterns {
+		slog.Error("not enough points")
+		return nil, errors.New("not enough points")
+	}
+	if minStellium < MinStellium {
+		slog.Error("minimum for stellium too small")
+		return nil, fmt.Errorf("minimum for stellium %d should be >= %d", minStellium, MinStellium)
+	}
+	if len(cusps) > 0 && len(cusps) < MinCuspsForPatterns {
+		slog.Error("not enough cusps")
+		return nil, errors.New("not enough cusps")
+	}
+	pointIds := make(map[domain.ChartPoint]bool)
+	for _, point := range points {
+		if point.Position < domain.MinLongitude || point.Position >= domain.MaxLongitude {
+			slog.Error("point is out of range", "longitude", fmt.Sprint(point.Position))
+			return nil, fmt.Errorf("point %d is out of range, longitude is %f", point.Id, point.Position)
+		}
+		pointIds[point.Id] = true
+	}
+	for _, cusp := range cusps {
+		if cusp < domain.MinLongitude || cusp >= domain.MaxLongitude {
+			slog.Error("cusp is out of range", "longitude", fmt.Sprint(cusp))
+			return nil, fmt.Errorf("cusp %f is out of range", cusp)
+		}
+	}
+	for _, aspect := range aspects {
+		if !pointIds[aspect.Pos1.Id] || !pointIds[aspect.Pos2.Id] {
+			slog.Error("aspect refers to unknown point")
+			return nil, fmt.Errorf("aspect between %d and %d refers to a point that is not in points",
+				aspect.Pos1.Id, aspect.Pos2.Id)
+		}
+	}
+	slog.Info("Completed recognition of aspect patterns")
+	return aps.patCalc.CalcPatterns(aspects, points, cusps, minStellium)
+}
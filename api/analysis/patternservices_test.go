@@ -0,0 +1,71 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apianalysis
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+var patternPoints = []domain.SinglePosition{
+	{Id: 0, Position: 10.0},
+	{Id: 1, Position: 130.0},
+	{Id: 2, Position: 250.0},
+}
+
+var patternAspects = []domain.ActualAspect{
+	{Pos1: patternPoints[0], Pos2: patternPoints[1], ActualAspect: domain.Trine, Exactness: 100},
+	{Pos1: patternPoints[0], Pos2: patternPoints[2], ActualAspect: domain.Trine, Exactness: 100},
+	{Pos1: patternPoints[1], Pos2: patternPoints[2], ActualAspect: domain.Trine, Exactness: 100},
+}
+
+func TestAspectPatternsHappyFlow(t *testing.T) {
+	aps := NewAspectPatternService()
+	result, err := aps.AspectPatterns(patternAspects, patternPoints, []float64{}, 3)
+	if err != nil {
+		t.Fatalf("AspectPatterns returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Pattern != domain.PatternGrandTrine {
+		t.Errorf("AspectPatterns expected a grand trine, got %v", result)
+	}
+}
+
+func TestAspectPatternsNotEnoughPoints(t *testing.T) {
+	aps := NewAspectPatternService()
+	result, err := aps.AspectPatterns([]domain.ActualAspect{}, patternPoints[0:2], []float64{}, 3)
+	if err == nil || result != nil {
+		t.Errorf("AspectPatterns should have returned nil and an error for not enough points")
+	}
+}
+
+func TestAspectPatternsMinStelliumTooSmall(t *testing.T) {
+	aps := NewAspectPatternService()
+	result, err := aps.AspectPatterns(patternAspects, patternPoints, []float64{}, 2)
+	if err == nil || result != nil {
+		t.Errorf("AspectPatterns should have returned nil and an error for a stellium of 2 points")
+	}
+}
+
+func TestAspectPatternsCuspOutOfRange(t *testing.T) {
+	aps := NewAspectPatternService()
+	result, err := aps.AspectPatterns(patternAspects, patternPoints, []float64{10.0, 360.0}, 3)
+	if err == nil || result != nil {
+		t.Errorf("AspectPatterns should have returned nil and an error for a cusp out of range")
+	}
+}
+
+func TestAspectPatternsUnknownPointInAspect(t *testing.T) {
+	aspects := []domain.ActualAspect{
+		{Pos1: patternPoints[0], Pos2: domain.SinglePosition{Id: 8, Position: 190.0}, ActualAspect: domain.Opposition},
+	}
+	aps := NewAspectPatternService()
+	result, err := aps.AspectPatterns(aspects, patternPoints, []float64{}, 3)
+	if err == nil || result != nil {
+		t.Errorf("AspectPatterns should have returned nil and an error for an aspect with an unknown point")
+	}
+}
@@ -31,6 +31,12 @@ func NewFullChartService() FullChartServer {
 // PRE MinJdGeneral < FullChartRequest.Jd < NaxJdGeneral (jd between -2946707.5 and 7865293.5)
 // PRE MinGeoLong <= FullChartRequest.Geolong < MaxGeoLong (geolong between -180.0 and 180.0)
 // PRE MinGeoLat < = FullChartRequest.GeoLat < MaxGeoLat (geolat between -90.0 and 90.0)
+// PRE MinElevation <= FullChartRequest.Elevation <= MaxElevation (elevation between -500.0 and 10000.0 meters)
+// PRE MinAtmPressure <= FullChartRequest.AtmPressure <= MaxAtmPressure (pressure between 0.0 and 1100.0 hPa)
+// PRE MinAtmTemperature <= FullChartRequest.AtmTemperature <= MaxAtmTemperature (temperature between -90.0 and 60.0 Celsius)
+// PRE FullChartRequest.HouseSys is HousesNone for heliocentric, barycentric and planetocentric positions
+// PRE for barycentric and planetocentric positions all points are calculated by the SE
+// PRE for planetocentric positions FullChartRequest.ObsCenter is a planet, the Sun or the Moon and is not one of the points
 // POST No errors: returns calculated full chart response, otherwise returns empty full chart response
 func (fcs FullChartService) CalcFullChart(request domain.FullChartRequest) (domain.FullChartResponse, error) {
 	slog.Info("Start calculation of full chart")
@@ -43,23 +49,47 @@ func (fcs FullChartService) CalcFullChart(request domain.FullChartRequest) (doma
 		EastPoint: domain.HousePosResult{},
 		Cusps:     nil,
 	}
+	if err := validateFullChartRequest(request); err != nil {
+		return emptyResponse, err
+	}
+	slog.Info("Completed calculation of full chart")
+	result, err := fcs.fcc.CalcFullChart(request)
+	return result, err
+}
+
+func validateFullChartRequest(request domain.FullChartRequest) error {
 	if len(request.Points) <= 0 {
 		slog.Error("points is empty")
-		return emptyResponse, errors.New("points is empty")
+		return errors.New("points is empty")
 	}
 	if request.Jd < domain.MinJdGeneral || request.Jd > domain.MaxJdGeneral {
 		slog.Error("jd is out of range")
-		return emptyResponse, errors.New("jd is out of range")
+		return errors.New("jd is out of range")
 	}
 	if request.GeoLong < domain.MinGeoLong || request.GeoLong > domain.MaxGeoLong {
 		slog.Error("geoLong is out of range")
-		return emptyResponse, errors.New("geoLong is out of range")
+		return errors.New("geoLong is out of range")
 	}
 	if request.GeoLat < domain.MinGeoLat || request.GeoLat > domain.MaxGeoLat {
 		slog.Error("geoLat is out of range")
-		return emptyResponse, errors.New("geoLat is out of range")
+		return errors.New("geoLat is out of range")
 	}
-	slog.Info("Completed calculation of full chart")
-	result, err := fcs.fcc.CalcFullChart(request)
-	return result, err
+	if request.Elevation < domain.MinElevation || request.Elevation > domain.MaxElevation {
+		slog.Error("elevation is out of range")
+		return errors.New("elevation is out of range")
+	}
+	if request.AtmPressure < domain.MinAtmPressure || request.AtmPressure > domain.MaxAtmPressure {
+		slog.Error("atmospheric pressure is out of range")
+		return errors.New("atmospheric pressure is out of range")
+	}
+	if request.AtmTemperature < domain.MinAtmTemperature || request.AtmTemperature > domain.MaxAtmTemperature {
+		slog.Error("atmospheric temperature is out of range")
+		return errors.New("atmospheric temperature is out of range")
+	}
+	if request.HouseSys != domain.HousesNone && (request.ObsPos == domain.ObsPosHeliocentric ||
+		request.ObsPos == domain.ObsPosBarycentric || request.ObsPos == domain.ObsPosPlanetocentric) {
+		slog.Error("houses not supported for observer position")
+		return errors.New("houses are only supported for geocentric and topocentric positions")
+	}
+	return validateObserver(request.ObsPos, request.ObsCenter, request.Points)
 }
@@ -162,3 +162,94 @@ func TestCalcFullChartGeoLatTooLarge(T *testing.T) {
 		T.Errorf("Expected error for geolat too large, got nil")
 	}
 }
+
+func TestCalcFullChartElevationTooLarge(T *testing.T) {
+	request := domain.FullChartRequest{
+		Points: []domain.ChartPoint{
+			domain.Sun,
+			domain.Moon,
+		},
+		HouseSys:  domain.HousesAlcabitius,
+		Ayanamsha: domain.AyanNone,
+		CoordSys:  domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosTopocentric,
+		ProjType:  domain.ProjType2D,
+		Jd:        123456.789,
+		GeoLong:   81.0,
+		GeoLat:    52.0,
+		Elevation: domain.MaxElevation + 1.0,
+	}
+	fcc := NewFullChartService()
+	_, err := fcc.CalcFullChart(request)
+	if err == nil {
+		T.Errorf("Expected error for elevation too large, got nil")
+	}
+}
+
+func TestCalcFullChartAtmPressureTooLarge(T *testing.T) {
+	request := domain.FullChartRequest{
+		Points: []domain.ChartPoint{
+			domain.Sun,
+			domain.Moon,
+		},
+		HouseSys:    domain.HousesAlcabitius,
+		Ayanamsha:   domain.AyanNone,
+		CoordSys:    domain.CoordEcliptical,
+		ObsPos:      domain.ObsPosGeocentric,
+		ProjType:    domain.ProjType2D,
+		Jd:          123456.789,
+		GeoLong:     81.0,
+		GeoLat:      52.0,
+		AtmPressure: domain.MaxAtmPressure + 1.0,
+	}
+	fcc := NewFullChartService()
+	_, err := fcc.CalcFullChart(request)
+	if err == nil {
+		T.Errorf("Expected error for atmospheric pressure too large, got nil")
+	}
+}
+
+func TestCalcFullChartHousesForHeliocentric(T *testing.T) {
+	request := domain.FullChartRequest{
+		Points: []domain.ChartPoint{
+			domain.Moon,
+			domain.Mars,
+		},
+		HouseSys:  domain.HousesPlacidus,
+		Ayanamsha: domain.AyanNone,
+		CoordSys:  domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosHeliocentric,
+		ProjType:  domain.ProjType2D,
+		Jd:        2451545.0,
+		GeoLong:   5.0,
+		GeoLat:    52.0,
+	}
+	fcc := NewFullChartService()
+	_, err := fcc.CalcFullChart(request)
+	if err == nil {
+		T.Errorf("Expected error for houses in a heliocentric chart, got nil")
+	}
+}
+
+func TestCalcFullChartPlanetocentricCenterInPoints(T *testing.T) {
+	request := domain.FullChartRequest{
+		Points: []domain.ChartPoint{
+			domain.Sun,
+			domain.Mars,
+		},
+		HouseSys:  domain.HousesNone,
+		Ayanamsha: domain.AyanNone,
+		CoordSys:  domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosPlanetocentric,
+		ObsCenter: domain.Mars,
+		ProjType:  domain.ProjType2D,
+		Jd:        2451545.0,
+		GeoLong:   5.0,
+		GeoLat:    52.0,
+	}
+	fcc := NewFullChartService()
+	_, err := fcc.CalcFullChart(request)
+	if err == nil {
+		T.Errorf("Expected error for center that is also used as point, got nil")
+	}
+}
@@ -52,6 +52,11 @@ func NewPointRangeService() PointRangeService {
 // PRE MinGeoLat < request.GeoLat < MaxGeoLat
 // PRE MinArmc <= request.Armc < MaxArmc
 // PRE MinObliquity < request.Obliquity < MaxObliquity
+// PRE MinElevation <= request.Elevation <= MaxElevation
+// PRE MinAtmPressure <= request.AtmPressure <= MaxAtmPressure
+// PRE MinAtmTemperature <= request.AtmTemperature <= MaxAtmTemperature
+// PRE for barycentric and planetocentric positions all points are calculated by the SE
+// PRE for planetocentric positions request.ObsCenter is a planet, the Sun or the Moon and is not one of the points
 // POST No errors -> returns calculated chart, otherwise returns nil
 func (fps FullPointService) FullPositions(request domain.PointPositionsRequest) ([]domain.PointPosResult, error) {
 	if len(request.Points) < 1 {
@@ -78,6 +83,21 @@ func (fps FullPointService) FullPositions(request domain.PointPositionsRequest)
 		slog.Error("Obliquity out of range")
 		return nil, fmt.Errorf("obliquity %f is out of range", request.Obliquity)
 	}
+	if request.Elevation < domain.MinElevation || request.Elevation > domain.MaxElevation {
+		slog.Error("Elevation out of range")
+		return nil, fmt.Errorf("elevation %f is out of range", request.Elevation)
+	}
+	if request.AtmPressure < domain.MinAtmPressure || request.AtmPressure > domain.MaxAtmPressure {
+		slog.Error("AtmPressure out of range")
+		return nil, fmt.Errorf("atmospheric pressure %f is out of range", request.AtmPressure)
+	}
+	if request.AtmTemperature < domain.MinAtmTemperature || request.AtmTemperature > domain.MaxAtmTemperature {
+		slog.Error("AtmTemperature out of range")
+		return nil, fmt.Errorf("atmospheric temperature %f is out of range", request.AtmTemperature)
+	}
+	if err := validateObserver(request.ObsPos, request.ObsCenter, request.Points); err != nil {
+		return nil, err
+	}
 	positions, err := fps.fpCalc.CalcPointPos(request)
 	if err != nil {
 		slog.Error("Error calculating full points", "error", err, "request", request)
@@ -86,6 +106,44 @@ func (fps FullPointService) FullPositions(request domain.PointPositionsRequest)
 	return positions, err
 }
 
+// DefinePointRange calculates the positions or speeds for a range of julian day numbers.
+// PRE request.Point is calculated by the SE
+// PRE for planetocentric positions request.ObsCenter is a planet, the Sun or the Moon and differs from request.Point
+// POST No errors -> returns the range, otherwise returns nil
 func (prs PointRangeService) DefinePointRange(request domain.PointRangeRequest) ([]domain.PointRangeResult, error) {
+	if domain.AllChartPoints()[request.Point].CalcCat != domain.CalcSe {
+		slog.Error("Point not supported")
+		return nil, fmt.Errorf("point %d is not supported for a range", request.Point)
+	}
+	if err := validateObserver(request.ObsPos, request.ObsCenter, []domain.ChartPoint{request.Point}); err != nil {
+		return nil, err
+	}
 	return prs.prCalc.CalcPointRange(request)
 }
+
+// validateObserver checks if the points and the center can be used for the observer position.
+func validateObserver(obsPos domain.ObserverPosition, center domain.ChartPoint, points []domain.ChartPoint) error {
+	if obsPos != domain.ObsPosBarycentric && obsPos != domain.ObsPosPlanetocentric {
+		return nil
+	}
+	allPoints := domain.AllChartPoints()
+	for _, point := range points {
+		if allPoints[point].CalcCat != domain.CalcSe {
+			slog.Error("Point not supported for observer position")
+			return fmt.Errorf("point %d is not supported for observer position %d", point, obsPos)
+		}
+	}
+	if obsPos == domain.ObsPosPlanetocentric {
+		if center < domain.Sun || (center > domain.Pluto && center != domain.Earth) {
+			slog.Error("Center not supported")
+			return fmt.Errorf("center %d is not supported for planetocentric positions", center)
+		}
+		for _, point := range points {
+			if point == center {
+				slog.Error("Center is also used as point")
+				return fmt.Errorf("center %d cannot be used as point", center)
+			}
+		}
+	}
+	return nil
+}
@@ -264,3 +264,97 @@ func TestFullPositionsObliquityTooLarge(t *testing.T) {
 		t.Errorf("full positions: expected nil for obliquity that is too large")
 	}
 }
+
+func TestFullPositionsElevationTooSmall(t *testing.T) {
+	request := domain.PointPositionsRequest{
+		Points:    []domain.ChartPoint{domain.Sun, domain.Moon},
+		JdUt:      123456.789,
+		GeoLong:   90.0,
+		GeoLat:    11.0,
+		Elevation: domain.MinElevation - 1.0,
+		Armc:      1.0,
+		Obliquity: 23.447,
+		Coord:     0.0,
+		ObsPos:    0.0,
+		ProjType:  0.0,
+		Ayanamsha: domain.AyanNone,
+	}
+	fps := NewFullPointService()
+	result, err := fps.FullPositions(request)
+	if err == nil {
+		t.Errorf("full positions: expected error for elevation that is too small")
+	}
+	if result != nil {
+		t.Errorf("full positions: expected nil for elevation that is too small")
+	}
+}
+
+func TestFullPositionsAtmTemperatureTooLarge(t *testing.T) {
+	request := domain.PointPositionsRequest{
+		Points:         []domain.ChartPoint{domain.Sun, domain.Moon},
+		JdUt:           123456.789,
+		GeoLong:        90.0,
+		GeoLat:         11.0,
+		AtmTemperature: domain.MaxAtmTemperature + 1.0,
+		Armc:           1.0,
+		Obliquity:      23.447,
+		Coord:          0.0,
+		ObsPos:         0.0,
+		ProjType:       0.0,
+		Ayanamsha:      domain.AyanNone,
+	}
+	fps := NewFullPointService()
+	result, err := fps.FullPositions(request)
+	if err == nil {
+		t.Errorf("full positions: expected error for atmospheric temperature that is too large")
+	}
+	if result != nil {
+		t.Errorf("full positions: expected nil for atmospheric temperature that is too large")
+	}
+}
+
+func TestFullPositionsBarycentricPointNotSupported(t *testing.T) {
+	request := domain.PointPositionsRequest{
+		Points:    []domain.ChartPoint{domain.Sun, domain.PersephoneCarteret},
+		JdUt:      2451545.0,
+		GeoLong:   90.0,
+		GeoLat:    11.0,
+		Armc:      1.0,
+		Obliquity: 23.447,
+		Coord:     domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosBarycentric,
+		ProjType:  domain.ProjType2D,
+		Ayanamsha: domain.AyanNone,
+	}
+	fps := NewFullPointService()
+	result, err := fps.FullPositions(request)
+	if err == nil {
+		t.Errorf("full positions: expected error for barycentric position of a hypothetical point")
+	}
+	if result != nil {
+		t.Errorf("full positions: expected nil for barycentric position of a hypothetical point")
+	}
+}
+
+func TestDefinePointRangePlanetocentricCenterNotSupported(t *testing.T) {
+	request := domain.PointRangeRequest{
+		Point:     domain.Sun,
+		JdStart:   2451545.0,
+		JdEnd:     2451546.0,
+		Interval:  1.0,
+		Coord:     domain.CoordEcliptical,
+		MainValue: true,
+		Position:  true,
+		ObsPos:    domain.ObsPosPlanetocentric,
+		ObsCenter: domain.Ascendant,
+		Ayanamsha: domain.AyanNone,
+	}
+	prs := NewPointRangeService()
+	result, err := prs.DefinePointRange(request)
+	if err == nil {
+		t.Errorf("point range: expected error for unsupported center")
+	}
+	if result != nil {
+		t.Errorf("point range: expected nil for unsupported center")
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apicalc
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/analysis"
+	"errors"
+	"log/slog"
+)
+
+// RelationChartServer provides services for the calculation of composite charts and Davison charts.
+type RelationChartServer interface {
+	CalcComposite(requestA domain.FullChartRequest, requestB domain.FullChartRequest) (domain.FullChartResponse, error)
+	CalcDavison(requestA domain.FullChartRequest, requestB domain.FullChartRequest) (domain.FullChartResponse, error)
+}
+
+type RelationChartService struct {
+	rcc analysis.RelationChartCalculator
+}
+
+func NewRelationChartService() RelationChartServer {
+	return RelationChartService{analysis.NewRelationChartCalculation()}
+}
+
+// CalcComposite handles the calculation of a composite chart, the midpoints of all positions in two charts.
+// PRE all PRE conditions for CalcFullChart, for requestA and for requestB
+// PRE requestA and requestB contain the same points and the same housesystem
+// POST No errors: returns composite chart, otherwise returns empty full chart response and error
+func (rcs RelationChartService) CalcComposite(requestA domain.FullChartRequest, requestB domain.FullChartRequest) (domain.FullChartResponse, error) {
+	slog.Info("Start calculation of composite chart")
+	if err := validateRelationChartRequests(requestA, requestB); err != nil {
+		return domain.FullChartResponse{}, err
+	}
+	if requestA.HouseSys != requestB.HouseSys {
+		slog.Error("different housesystems")
+		return domain.FullChartResponse{}, errors.New("composite requires the same housesystem for both charts")
+	}
+	if len(requestA.Points) != len(requestB.Points) {
+		slog.Error("different points")
+		return domain.FullChartResponse{}, errors.New("composite requires the same points for both charts")
+	}
+	pointsB := make(map[domain.ChartPoint]bool)
+	for _, point := range requestB.Points {
+		pointsB[point] = true
+	}
+	for _, point := range requestA.Points {
+		if !pointsB[point] {
+			slog.Error("different points")
+			return domain.FullChartResponse{}, errors.New("composite requires the same points for both charts")
+		}
+	}
+	slog.Info("Completed calculation of composite chart")
+	return rcs.rcc.CalcComposite(requestA, requestB)
+}
+
+// CalcDavison handles the calculation of a Davison chart, a chart for the midpoint in time and space of two charts.
+// All settings, except date, time, location and atmospheric conditions, are taken from requestA.
+// PRE all PRE conditions for CalcFullChart, for requestA and for requestB
+// POST No errors: returns Davison chart, otherwise returns empty full chart response and error
+func (rcs RelationChartService) CalcDavison(requestA domain.FullChartRequest, requestB domain.FullChartRequest) (domain.FullChartResponse, error) {
+	slog.Info("Start calculation of Davison chart")
+	if err := validateRelationChartRequests(requestA, requestB); err != nil {
+		return domain.FullChartResponse{}, err
+	}
+	slog.Info("Completed calculation of Davison chart")
+	return rcs.rcc.CalcDavison(requestA, requestB)
+}
+
+func validateRelationChartRequests(requestA domain.FullChartRequest, requestB domain.FullChartRequest) error {
+	if err := validateFullChartRequest(requestA); err != nil {
+		return err
+	}
+	return validateFullChartRequest(requestB)
+}
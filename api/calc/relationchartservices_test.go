@@ -0,0 +1,57 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apicalc
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func relationRequest(points []domain.ChartPoint, houseSys domain.HouseSystem, jd float64) domain.FullChartRequest {
+	return domain.FullChartRequest{
+		Points:    points,
+		HouseSys:  houseSys,
+		Ayanamsha: domain.AyanNone,
+		CoordSys:  domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosGeocentric,
+		ProjType:  domain.ProjType2D,
+		Jd:        jd,
+		GeoLong:   5.0,
+		GeoLat:    52.0,
+	}
+}
+
+func TestCalcCompositeDifferentHouseSystems(t *testing.T) {
+	requestA := relationRequest([]domain.ChartPoint{domain.Sun, domain.Moon}, domain.HousesPlacidus, 2451545.0)
+	requestB := relationRequest([]domain.ChartPoint{domain.Sun, domain.Moon}, domain.HousesKoch, 2451000.0)
+	rcs := NewRelationChartService()
+	_, err := rcs.CalcComposite(requestA, requestB)
+	if err == nil {
+		t.Errorf("Expected error for different housesystems, got nil")
+	}
+}
+
+func TestCalcCompositeDifferentPoints(t *testing.T) {
+	requestA := relationRequest([]domain.ChartPoint{domain.Sun, domain.Moon}, domain.HousesPlacidus, 2451545.0)
+	requestB := relationRequest([]domain.ChartPoint{domain.Sun, domain.Mars}, domain.HousesPlacidus, 2451000.0)
+	rcs := NewRelationChartService()
+	_, err := rcs.CalcComposite(requestA, requestB)
+	if err == nil {
+		t.Errorf("Expected error for different points, got nil")
+	}
+}
+
+func TestCalcDavisonJdTooLate(t *testing.T) {
+	requestA := relationRequest([]domain.ChartPoint{domain.Sun}, domain.HousesPlacidus, 2451545.0)
+	requestB := relationRequest([]domain.ChartPoint{domain.Sun}, domain.HousesPlacidus, domain.MaxJdGeneral+1.0)
+	rcs := NewRelationChartService()
+	_, err := rcs.CalcDavison(requestA, requestB)
+	if err == nil {
+		t.Errorf("Expected error for jd that is too late, got nil")
+	}
+}
@@ -46,7 +46,7 @@ func TestFullChartHelioPositions(t *testing.T) {
 			domain.Jupiter,
 			domain.Pluto,
 		},
-		HouseSys:  domain.HousesRegiomontanus,
+		HouseSys:  domain.HousesNone,
 		Ayanamsha: domain.AyanNone,
 		CoordSys:  domain.CoordEcliptical,
 		ObsPos:    domain.ObsPosHeliocentric,
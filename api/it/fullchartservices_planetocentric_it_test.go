@@ -0,0 +1,69 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package it
+
+import (
+	apicalc "enigma-ar/api/calc"
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+// Integration tests for the calculation of barycentric and planetocentric positions
+
+func TestFullChartPlanetocentricFromSunMatchesHeliocentric(t *testing.T) {
+	request := domain.FullChartRequest{
+		Points:    []domain.ChartPoint{domain.Mars, domain.Jupiter},
+		HouseSys:  domain.HousesNone,
+		Ayanamsha: domain.AyanNone,
+		CoordSys:  domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosHeliocentric,
+		ProjType:  domain.ProjType2D,
+		Jd:        2451545.0,
+		GeoLong:   0.0,
+		GeoLat:    51.5,
+	}
+	calcService := apicalc.NewFullChartService()
+	helio, err := calcService.CalcFullChart(request)
+	if err != nil {
+		t.Fatalf("Integration test for heliocentric positions failed with error: %v", err)
+	}
+	request.ObsPos = domain.ObsPosPlanetocentric
+	request.ObsCenter = domain.Sun
+	pctr, err := calcService.CalcFullChart(request)
+	if err != nil {
+		t.Fatalf("Integration test for planetocentric positions failed with error: %v", err)
+	}
+	for i := range request.Points {
+		if math.Abs(helio.Points[i].LonPos-pctr.Points[i].LonPos) > 0.001 {
+			t.Errorf("Integration test for planetocentric positions: expected %f, got %f", helio.Points[i].LonPos, pctr.Points[i].LonPos)
+		}
+	}
+}
+
+func TestFullChartBarycentricSunNearCenter(t *testing.T) {
+	calcService := apicalc.NewFullChartService()
+	result, err := calcService.CalcFullChart(domain.FullChartRequest{
+		Points:    []domain.ChartPoint{domain.Sun},
+		HouseSys:  domain.HousesNone,
+		Ayanamsha: domain.AyanNone,
+		CoordSys:  domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosBarycentric,
+		ProjType:  domain.ProjType2D,
+		Jd:        2451545.0,
+		GeoLong:   0.0,
+		GeoLat:    51.5,
+	})
+	if err != nil {
+		t.Fatalf("Integration test for barycentric positions failed with error: %v", err)
+	}
+	// the distance between the Sun and the barycenter is less than 0.02 AU
+	if result.Points[0].RadvPos > 0.02 {
+		t.Errorf("Integration test for barycentric positions: distance of Sun %f is too large", result.Points[0].RadvPos)
+	}
+}
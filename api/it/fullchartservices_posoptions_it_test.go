@@ -0,0 +1,48 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package it
+
+import (
+	apicalc "enigma-ar/api/calc"
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+// Integration tests for apparent and astrometric positions.
+
+func TestFullChartAstrometricSunDiffersByAberration(t *testing.T) {
+	request := domain.FullChartRequest{
+		Points:    []domain.ChartPoint{domain.Sun},
+		HouseSys:  domain.HousesPlacidus,
+		Ayanamsha: domain.AyanNone,
+		CoordSys:  domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosGeocentric,
+		ProjType:  domain.ProjType2D,
+		Jd:        2451545.0,
+		GeoLong:   0.0,
+		GeoLat:    51.5,
+	}
+	calcService := apicalc.NewFullChartService()
+	apparent, err := calcService.CalcFullChart(request)
+	if err != nil {
+		t.Fatalf("Integration test for apparent positions failed with error: %v", err)
+	}
+	request.PosOptions = domain.AstrometricPositions()
+	request.PosOptions.NoNutation = true
+	astrometric, err := calcService.CalcFullChart(request)
+	if err != nil {
+		t.Fatalf("Integration test for astrometric positions failed with error: %v", err)
+	}
+	// aberration for the Sun is approx. -20.5", nutation in longitude at 2000/1/1 is approx. -13.9"
+	expectedDiff := (-20.5 - 13.9) / 3600.0
+	diff := apparent.Points[0].LonPos - astrometric.Points[0].LonPos
+	if math.Abs(diff-expectedDiff) > 0.5/3600.0 {
+		t.Errorf("Integration test for astrometric positions: expected difference %f, got %f", expectedDiff, diff)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package it
+
+import (
+	apiprogressive "enigma-ar/api/progressive"
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+// Integration tests for lunations, expected values from published tables, rounded to the minute.
+
+const deltaLunation = 0.001 // approx. 1.5 minutes
+
+func TestMainPhasesJanuary2000(t *testing.T) {
+	ls := apiprogressive.NewLunationService()
+	result, err := ls.MainPhases(2451544.5, 2451575.5) // 2000/1/1 0:00 UT - 2000/2/1 0:00 UT
+	if err != nil {
+		t.Fatalf("Integration test for main phases: %v", err)
+	}
+	expectedJds := []float64{
+		2451550.2597, // new moon 2000/1/6 18:14 UT
+		2451558.0653, // first quarter 2000/1/14 13:34 UT
+		2451564.6944, // full moon 2000/1/21 4:40 UT
+		2451571.8312, // last quarter 2000/1/28 7:57 UT
+	}
+	if len(result) != len(expectedJds) {
+		t.Fatalf("Integration test for main phases: expected %d events, got %d", len(expectedJds), len(result))
+	}
+	for i, jd := range expectedJds {
+		if math.Abs(result[i].Jd-jd) > deltaLunation {
+			t.Errorf("Integration test for main phases: expected jd %f, got %f", jd, result[i].Jd)
+		}
+	}
+}
+
+func TestNatalPhaseJanuary2000(t *testing.T) {
+	ls := apiprogressive.NewLunationService()
+	result, err := ls.NatalPhase(2451545.0) // 2000/1/1 12:00 UT
+	if err != nil {
+		t.Fatalf("Integration test for natal phase: %v", err)
+	}
+	if result.Phase != domain.PhaseLastQuarter {
+		t.Errorf("Integration test for natal phase: expected last quarter phase, got %d", result.Phase)
+	}
+	if math.Abs(result.JdPrenatalNewMoon-2451520.4389) > deltaLunation { // 1999/12/7 22:32 UT
+		t.Errorf("Integration test for natal phase: unexpected prenatal new moon %f", result.JdPrenatalNewMoon)
+	}
+	if math.Abs(result.JdPrenatalFullMoon-2451535.2299) > deltaLunation { // 1999/12/22 17:31 UT
+		t.Errorf("Integration test for natal phase: unexpected prenatal full moon %f", result.JdPrenatalFullMoon)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package it
+
+import (
+	apicalc "enigma-ar/api/calc"
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+// Integration tests for the calculation of composite charts and Davison charts
+
+func relationChartRequest(jd, geoLong, geoLat float64) domain.FullChartRequest {
+	return domain.FullChartRequest{
+		Points:    []domain.ChartPoint{domain.Sun, domain.Moon},
+		HouseSys:  domain.HousesPlacidus,
+		Ayanamsha: domain.AyanNone,
+		CoordSys:  domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosGeocentric,
+		ProjType:  domain.ProjType2D,
+		Jd:        jd,
+		GeoLong:   geoLong,
+		GeoLat:    geoLat,
+	}
+}
+
+func TestCompositeSunIsMidpoint(t *testing.T) {
+	requestA := relationChartRequest(2451545.0, 5.0, 52.0)   // Sun at about 280 degrees
+	requestB := relationChartRequest(2451700.0, -74.0, 40.7) // Sun at about 73 degrees
+	calcService := apicalc.NewFullChartService()
+	chartA, errA := calcService.CalcFullChart(requestA)
+	chartB, errB := calcService.CalcFullChart(requestB)
+	if errA != nil || errB != nil {
+		t.Fatalf("Integration test for composite failed with errors: %v %v", errA, errB)
+	}
+	composite, err := apicalc.NewRelationChartService().CalcComposite(requestA, requestB)
+	if err != nil {
+		t.Fatalf("Integration test for composite failed with error: %v", err)
+	}
+	expected := math.Mod((chartA.Points[0].LonPos+chartB.Points[0].LonPos+360.0)/2.0, 360.0)
+	if math.Abs(composite.Points[0].LonPos-expected) > 0.00001 {
+		t.Errorf("Integration test for composite: expected Sun at %f, got %f", expected, composite.Points[0].LonPos)
+	}
+	if len(composite.Cusps) != len(chartA.Cusps) {
+		t.Errorf("Integration test for composite: expected %d cusps, got %d", len(chartA.Cusps), len(composite.Cusps))
+	}
+}
+
+func TestDavisonIsChartForMidpointInTimeAndSpace(t *testing.T) {
+	requestA := relationChartRequest(2451545.0, 5.0, 52.0)
+	requestB := relationChartRequest(2451700.0, -75.0, 40.0)
+	davison, err := apicalc.NewRelationChartService().CalcDavison(requestA, requestB)
+	if err != nil {
+		t.Fatalf("Integration test for Davison failed with error: %v", err)
+	}
+	midRequest := relationChartRequest(2451622.5, -35.0, 46.0)
+	expected, err := apicalc.NewFullChartService().CalcFullChart(midRequest)
+	if err != nil {
+		t.Fatalf("Integration test for Davison failed with error: %v", err)
+	}
+	if math.Abs(davison.Points[1].LonPos-expected.Points[1].LonPos) > 0.00001 {
+		t.Errorf("Integration test for Davison: expected Moon at %f, got %f", expected.Points[1].LonPos, davison.Points[1].LonPos)
+	}
+	if math.Abs(davison.Mc.LonPos-expected.Mc.LonPos) > 0.00001 {
+		t.Errorf("Integration test for Davison: expected MC at %f, got %f", expected.Mc.LonPos, davison.Mc.LonPos)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apiprogressive
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/progressive"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// IngressServer provides services for the calculation of sign ingresses, house ingresses and out-of-bounds events.
+type IngressServer interface {
+	SignIngresses(request domain.IngressRequest) ([]domain.SignIngress, error)
+	HouseIngresses(request domain.IngressRequest, cusps []float64) ([]domain.HouseIngress, error)
+	OobCalendar(request domain.IngressRequest) ([]domain.OobEvent, error)
+}
+
+type IngressService struct {
+	ingrCalc progressive.IngressCalculator
+}
+
+func NewIngressService() *IngressService {
+	ingrCalculator := progressive.NewIngressCalculation()
+	return &IngressService{
+		ingrCalc: ingrCalculator,
+	}
+}
+
+const (
+	MinIntervalIngress = 0.0
+	MaxIntervalIngress = 30.0
+	MaxSamplesIngress  = 100_000
+	MinCuspsIngress    = 2
+)
+
+// SignIngresses handles the calculation of sign ingresses, the zodiac is tropical or sidereal, depending on the ayanamsha.
+// PRE request.Point is calculated by the SE
+// PRE request.ObsPos is geocentric, topocentric or heliocentric
+// PRE MinJdGeneral <= request.JdStart < request.JdEnd <= MaxJdGeneral
+// PRE 0.0 < request.Interval <= 30.0
+// PRE (request.JdEnd - request.JdStart) / request.Interval <= 100000
+// POST no errors -> returns ingresses, sorted by jd
+// POST errors: returns nil and error
+func (is IngressService) SignIngresses(request domain.IngressRequest) ([]domain.SignIngress, error) {
+	slog.Info("Started calculation of sign ingresses")
+	if err := validateIngressRequest(request); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed calculation of sign ingresses")
+	return is.ingrCalc.CalcSignIngresses(request)
+}
+
+// HouseIngresses handles the calculation of ingresses into houses, defined by the longitudes of the cusps.
+// The cusps start with the cusp for the first house, there is no empty placeholder.
+// PRE all PRE conditions for SignIngresses
+// PRE length cusps >= 2
+// PRE for all cusps : 0.0 <= cusp < 360.0
+// POST no errors -> returns ingresses, sorted by jd
+// POST errors: returns nil and error
+func (is IngressService) HouseIngresses(request domain.IngressRequest, cusps []float64) ([]domain.HouseIngress, error) {
+	slog.Info("Started calculation of house ingresses")
+	if err := validateIngressRequest(request); err != nil {
+		return nil, err
+	}
+	if len(cusps) < MinCuspsIngress {
+		slog.Error("not enough cusps")
+		return nil, errors.New("not enough cusps")
+	}
+	for _, cusp := range cusps {
+		if cusp < domain.MinLongitude || cusp >= domain.MaxLongitude {
+			slog.Error("cusp out of range")
+			return nil, fmt.Errorf("cusp %f is out of range", cusp)
+		}
+	}
+	slog.Info("Completed calculation of house ingresses")
+	return is.ingrCalc.CalcHouseIngresses(request, cusps)
+}
+
+// OobCalendar handles the calculation of the moments a point enters or leaves the out-of-bounds area.
+// The ayanamsha in the request is ignored.
+// PRE all PRE conditions for SignIngresses
+// POST no errors -> returns events, sorted by jd
+// POST errors: returns nil and error
+func (is IngressService) OobCalendar(request domain.IngressRequest) ([]domain.OobEvent, error) {
+	slog.Info("Started calculation of OOB calendar")
+	if err := validateIngressRequest(request); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed calculation of OOB calendar")
+	return is.ingrCalc.CalcOobEvents(request)
+}
+
+func validateIngressRequest(request domain.IngressRequest) error {
+	allPoints := domain.AllChartPoints()
+	if int(request.Point) < 0 || int(request.Point) >= len(allPoints) || allPoints[request.Point].CalcCat != domain.CalcSe {
+		slog.Error("point not supported")
+		return fmt.Errorf("point %d is not supported for ingresses", request.Point)
+	}
+	if request.ObsPos == domain.ObsPosBarycentric || request.ObsPos == domain.ObsPosPlanetocentric {
+		slog.Error("observer position not supported")
+		return fmt.Errorf("observer position %d is not supported for ingresses", request.ObsPos)
+	}
+	if request.JdStart < domain.MinJdGeneral || request.JdEnd > domain.MaxJdGeneral {
+		slog.Error("jd out of range")
+		return fmt.Errorf("jdStart %f or jdEnd %f is out of range", request.JdStart, request.JdEnd)
+	}
+	if request.JdStart >= request.JdEnd {
+		slog.Error("jdStart not before jdEnd")
+		return errors.New("jdStart must be before jdEnd")
+	}
+	if request.Interval <= MinIntervalIngress || request.Interval > MaxIntervalIngress {
+		slog.Error("interval out of range")
+		return fmt.Errorf("interval %f is out of range, must be > %f and <= %f", request.Interval, MinIntervalIngress, MaxIntervalIngress)
+	}
+	if (request.JdEnd-request.JdStart)/request.Interval > MaxSamplesIngress {
+		slog.Error("too many samples")
+		return errors.New("period too long for the given interval")
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apiprogressive
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func validIngressRequest() domain.IngressRequest {
+	return domain.IngressRequest{
+		Point:     domain.Mars,
+		JdStart:   2_451_545.0,
+		JdEnd:     2_451_910.0,
+		Interval:  1.0,
+		ObsPos:    domain.ObsPosGeocentric,
+		Ayanamsha: domain.AyanNone,
+	}
+}
+
+func TestSignIngressesJdStartAfterJdEnd(t *testing.T) {
+	request := validIngressRequest()
+	request.JdEnd = request.JdStart - 1.0
+	is := NewIngressService()
+	result, err := is.SignIngresses(request)
+	if err == nil {
+		t.Errorf("SignIngresses should have returned an error for jdStart after jdEnd")
+	}
+	if result != nil {
+		t.Errorf("SignIngresses should have returned nil for jdStart after jdEnd")
+	}
+}
+
+func TestSignIngressesJdOutOfRange(t *testing.T) {
+	request := validIngressRequest()
+	request.JdEnd = domain.MaxJdGeneral + 1.0
+	is := NewIngressService()
+	result, err := is.SignIngresses(request)
+	if err == nil {
+		t.Errorf("SignIngresses should have returned an error for jd out of range")
+	}
+	if result != nil {
+		t.Errorf("SignIngresses should have returned nil for jd out of range")
+	}
+}
+
+func TestSignIngressesIntervalZero(t *testing.T) {
+	request := validIngressRequest()
+	request.Interval = 0.0
+	is := NewIngressService()
+	result, err := is.SignIngresses(request)
+	if err == nil {
+		t.Errorf("SignIngresses should have returned an error for interval zero")
+	}
+	if result != nil {
+		t.Errorf("SignIngresses should have returned nil for interval zero")
+	}
+}
+
+func TestSignIngressesTooManySamples(t *testing.T) {
+	request := validIngressRequest()
+	request.JdEnd = request.JdStart + 1_000_000.0
+	is := NewIngressService()
+	result, err := is.SignIngresses(request)
+	if err == nil {
+		t.Errorf("SignIngresses should have returned an error for too many samples")
+	}
+	if result != nil {
+		t.Errorf("SignIngresses should have returned nil for too many samples")
+	}
+}
+
+func TestSignIngressesPointNotSupported(t *testing.T) {
+	request := validIngressRequest()
+	request.Point = domain.Ascendant
+	is := NewIngressService()
+	result, err := is.SignIngresses(request)
+	if err == nil {
+		t.Errorf("SignIngresses should have returned an error for unsupported point")
+	}
+	if result != nil {
+		t.Errorf("SignIngresses should have returned nil for unsupported point")
+	}
+}
+
+func TestHouseIngressesNotEnoughCusps(t *testing.T) {
+	is := NewIngressService()
+	result, err := is.HouseIngresses(validIngressRequest(), []float64{10.0})
+	if err == nil {
+		t.Errorf("HouseIngresses should have returned an error for not enough cusps")
+	}
+	if result != nil {
+		t.Errorf("HouseIngresses should have returned nil for not enough cusps")
+	}
+}
+
+func TestHouseIngressesCuspOutOfRange(t *testing.T) {
+	is := NewIngressService()
+	result, err := is.HouseIngresses(validIngressRequest(), []float64{10.0, 360.0})
+	if err == nil {
+		t.Errorf("HouseIngresses should have returned an error for cusp out of range")
+	}
+	if result != nil {
+		t.Errorf("HouseIngresses should have returned nil for cusp out of range")
+	}
+}
+
+func TestOobCalendarJdStartAfterJdEnd(t *testing.T) {
+	request := validIngressRequest()
+	request.JdEnd = request.JdStart
+	is := NewIngressService()
+	result, err := is.OobCalendar(request)
+	if err == nil {
+		t.Errorf("OobCalendar should have returned an error for jdStart not before jdEnd")
+	}
+	if result != nil {
+		t.Errorf("OobCalendar should have returned nil for jdStart not before jdEnd")
+	}
+}
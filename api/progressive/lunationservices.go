@@ -0,0 +1,100 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apiprogressive
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/progressive"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// LunationServer provides services for the lunation cycle: moments of specific elongations between Moon and Sun,
+// and the lunar phase of a chart.
+type LunationServer interface {
+	ElongationMoments(jdStart, jdEnd, elongation float64) ([]domain.LunarEvent, error)
+	MainPhases(jdStart, jdEnd float64) ([]domain.LunarEvent, error)
+	NatalPhase(jd float64) (domain.NatalLunarPhase, error)
+}
+
+type LunationService struct {
+	lunCalc progressive.LunationCalculator
+}
+
+func NewLunationService() *LunationService {
+	lunCalculator := progressive.NewLunationCalculation()
+	return &LunationService{
+		lunCalc: lunCalculator,
+	}
+}
+
+const MaxPeriodLunations = 100_000.0 // days
+
+// ElongationMoments handles the search for all moments that the Moon reaches the given elongation from the Sun.
+// PRE MinJdGeneral <= jdStart < jdEnd <= MaxJdGeneral
+// PRE jdEnd - jdStart <= 100000
+// PRE 0.0 <= elongation < 360.0
+// POST no errors -> returns events, sorted by jd
+// POST errors: returns nil and error
+func (ls LunationService) ElongationMoments(jdStart, jdEnd, elongation float64) ([]domain.LunarEvent, error) {
+	slog.Info("Started calculation of elongation moments")
+	if err := validateLunationPeriod(jdStart, jdEnd); err != nil {
+		return nil, err
+	}
+	if elongation < domain.MinLongitude || elongation >= domain.MaxLongitude {
+		slog.Error("elongation out of range")
+		return nil, fmt.Errorf("elongation %f is out of range", elongation)
+	}
+	slog.Info("Completed calculation of elongation moments")
+	return ls.lunCalc.CalcElongationMoments(jdStart, jdEnd, elongation)
+}
+
+// MainPhases handles the search for all new moons, first quarters, full moons and last quarters.
+// PRE MinJdGeneral <= jdStart < jdEnd <= MaxJdGeneral
+// PRE jdEnd - jdStart <= 100000
+// POST no errors -> returns events, sorted by jd
+// POST errors: returns nil and error
+func (ls LunationService) MainPhases(jdStart, jdEnd float64) ([]domain.LunarEvent, error) {
+	slog.Info("Started calculation of main lunar phases")
+	if err := validateLunationPeriod(jdStart, jdEnd); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed calculation of main lunar phases")
+	return ls.lunCalc.CalcMainPhases(jdStart, jdEnd)
+}
+
+// NatalPhase handles the calculation of the lunar phase and the prenatal lunations for a chart.
+// PRE MinJdGeneral + 32.0 <= jd <= MaxJdGeneral
+// POST no errors -> returns the natal phase
+// POST errors: returns empty result and error
+func (ls LunationService) NatalPhase(jd float64) (domain.NatalLunarPhase, error) {
+	slog.Info("Started calculation of natal lunar phase")
+	if jd-domain.SynodicMonthInDays-progressive.LunationMargin < domain.MinJdGeneral || jd > domain.MaxJdGeneral {
+		slog.Error("jd out of range")
+		return domain.NatalLunarPhase{}, fmt.Errorf("jd %f is out of range", jd)
+	}
+	slog.Info("Completed calculation of natal lunar phase")
+	return ls.lunCalc.CalcNatalPhase(jd)
+}
+
+func validateLunationPeriod(jdStart, jdEnd float64) error {
+	if jdStart < domain.MinJdGeneral || jdEnd > domain.MaxJdGeneral {
+		slog.Error("jd out of range")
+		return fmt.Errorf("jdStart %f or jdEnd %f is out of range", jdStart, jdEnd)
+	}
+	if jdStart >= jdEnd {
+		slog.Error("jdStart not before jdEnd")
+		return errors.New("jdStart must be before jdEnd")
+	}
+	if jdEnd-jdStart > MaxPeriodLunations {
+		slog.Error("period too long")
+		return errors.New("period too long for the calculation of lunations")
+	}
+	return nil
+}
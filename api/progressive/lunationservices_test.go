@@ -0,0 +1,54 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apiprogressive
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func TestElongationMomentsElongationOutOfRange(t *testing.T) {
+	ls := NewLunationService()
+	result, err := ls.ElongationMoments(2_451_545.0, 2_451_600.0, 360.0)
+	if err == nil {
+		t.Errorf("ElongationMoments should have returned an error for elongation out of range")
+	}
+	if result != nil {
+		t.Errorf("ElongationMoments should have returned nil for elongation out of range")
+	}
+}
+
+func TestElongationMomentsJdStartAfterJdEnd(t *testing.T) {
+	ls := NewLunationService()
+	result, err := ls.ElongationMoments(2_451_600.0, 2_451_545.0, 90.0)
+	if err == nil {
+		t.Errorf("ElongationMoments should have returned an error for jdStart after jdEnd")
+	}
+	if result != nil {
+		t.Errorf("ElongationMoments should have returned nil for jdStart after jdEnd")
+	}
+}
+
+func TestMainPhasesPeriodTooLong(t *testing.T) {
+	ls := NewLunationService()
+	result, err := ls.MainPhases(2_451_545.0, 2_451_545.0+MaxPeriodLunations+1.0)
+	if err == nil {
+		t.Errorf("MainPhases should have returned an error for a period that is too long")
+	}
+	if result != nil {
+		t.Errorf("MainPhases should have returned nil for a period that is too long")
+	}
+}
+
+func TestNatalPhaseJdOutOfRange(t *testing.T) {
+	ls := NewLunationService()
+	_, err := ls.NatalPhase(domain.MaxJdGeneral + 1.0)
+	if err == nil {
+		t.Errorf("NatalPhase should have returned an error for jd out of range")
+	}
+}
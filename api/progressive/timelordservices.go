@@ -0,0 +1,111 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apiprogressive
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/progressive"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// TimeLordServer provides services for the calculation of traditional time lords: profections and firdaria.
+type TimeLordServer interface {
+	AnnualProfections(request domain.TimeLordRequest) ([]domain.Profection, error)
+	MonthlyProfections(request domain.TimeLordRequest) ([]domain.Profection, error)
+	Firdaria(request domain.TimeLordRequest) ([]domain.FirdarPeriod, error)
+}
+
+type TimeLordService struct {
+	tlCalc progressive.TimeLordCalculator
+}
+
+func NewTimeLordService() *TimeLordService {
+	tlCalculator := progressive.NewTimeLordCalculation()
+	return &TimeLordService{
+		tlCalc: tlCalculator,
+	}
+}
+
+const MaxYearsTimeLords = 200.0
+
+// AnnualProfections handles the calculation of annual profections from the ascendant of the radix.
+// PRE MinJdGeneral <= request.JdRadix < request.JdEnd <= MaxJdGeneral
+// PRE request.JdStart < request.JdEnd
+// PRE (request.JdEnd - request.JdStart) <= 200 years
+// PRE 0.0 <= request.Radix.Asc.LonPos < 360.0
+// POST no errors -> returns profections, sorted by jd
+// POST errors: returns nil and error
+func (ts TimeLordService) AnnualProfections(request domain.TimeLordRequest) ([]domain.Profection, error) {
+	slog.Info("Started calculation of annual profections")
+	if err := validateTimeLordRequest(request); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed calculation of annual profections")
+	return ts.tlCalc.CalcAnnualProfections(request)
+}
+
+// MonthlyProfections handles the calculation of monthly profections from the ascendant of the radix.
+// PRE all PRE conditions for AnnualProfections
+// POST no errors -> returns profections, sorted by jd
+// POST errors: returns nil and error
+func (ts TimeLordService) MonthlyProfections(request domain.TimeLordRequest) ([]domain.Profection, error) {
+	slog.Info("Started calculation of monthly profections")
+	if err := validateTimeLordRequest(request); err != nil {
+		return nil, err
+	}
+	slog.Info("Completed calculation of monthly profections")
+	return ts.tlCalc.CalcMonthlyProfections(request)
+}
+
+// Firdaria handles the calculation of the periods and sub-periods of the firdaria.
+// PRE all PRE conditions for AnnualProfections
+// PRE request.Radix contains the Sun with 0.0 <= LonPos < 360.0
+// POST no errors -> returns periods, sorted by jd
+// POST errors: returns nil and error
+func (ts TimeLordService) Firdaria(request domain.TimeLordRequest) ([]domain.FirdarPeriod, error) {
+	slog.Info("Started calculation of firdaria")
+	if err := validateTimeLordRequest(request); err != nil {
+		return nil, err
+	}
+	for _, point := range request.Radix.Points {
+		if point.Point == domain.Sun && (point.LonPos < domain.MinLongitude || point.LonPos >= domain.MaxLongitude) {
+			slog.Error("longitude of Sun out of range")
+			return nil, fmt.Errorf("longitude of Sun %f is out of range", point.LonPos)
+		}
+	}
+	periods, err := ts.tlCalc.CalcFirdaria(request)
+	if err != nil {
+		slog.Error("calculation of firdaria failed", "error", err)
+		return nil, err
+	}
+	slog.Info("Completed calculation of firdaria")
+	return periods, nil
+}
+
+func validateTimeLordRequest(request domain.TimeLordRequest) error {
+	if request.JdRadix < domain.MinJdGeneral || request.JdEnd > domain.MaxJdGeneral {
+		slog.Error("jd out of range")
+		return fmt.Errorf("jdRadix %f or jdEnd %f is out of range", request.JdRadix, request.JdEnd)
+	}
+	if request.JdStart >= request.JdEnd || request.JdRadix >= request.JdEnd {
+		slog.Error("jdStart or jdRadix not before jdEnd")
+		return errors.New("jdStart and jdRadix must be before jdEnd")
+	}
+	if request.JdEnd-request.JdStart > MaxYearsTimeLords*domain.TropicalYearInDays {
+		slog.Error("period too long")
+		return fmt.Errorf("period must not exceed %f years", MaxYearsTimeLords)
+	}
+	asc := request.Radix.Asc.LonPos
+	if asc < domain.MinLongitude || asc >= domain.MaxLongitude {
+		slog.Error("ascendant out of range")
+		return fmt.Errorf("ascendant %f is out of range", asc)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package apiprogressive
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func validTimeLordRequest() domain.TimeLordRequest {
+	return domain.TimeLordRequest{
+		Radix: domain.FullChartResponse{
+			Points: []domain.PointPosResult{{Point: domain.Sun, LonPos: 45.0}},
+			Asc:    domain.HousePosResult{LonPos: 135.0},
+		},
+		JdRadix: 2_451_545.0,
+		JdStart: 2_451_545.0,
+		JdEnd:   2_451_545.0 + 20.0*domain.TropicalYearInDays,
+	}
+}
+
+func TestAnnualProfectionsJdStartAfterJdEnd(t *testing.T) {
+	request := validTimeLordRequest()
+	request.JdStart = request.JdEnd + 1.0
+	ts := NewTimeLordService()
+	result, err := ts.AnnualProfections(request)
+	if err == nil {
+		t.Errorf("AnnualProfections should have returned an error for jdStart after jdEnd")
+	}
+	if result != nil {
+		t.Errorf("AnnualProfections should have returned nil for jdStart after jdEnd")
+	}
+}
+
+func TestMonthlyProfectionsPeriodTooLong(t *testing.T) {
+	request := validTimeLordRequest()
+	request.JdEnd = request.JdStart + 201.0*domain.TropicalYearInDays
+	ts := NewTimeLordService()
+	result, err := ts.MonthlyProfections(request)
+	if err == nil {
+		t.Errorf("MonthlyProfections should have returned an error for a period that is too long")
+	}
+	if result != nil {
+		t.Errorf("MonthlyProfections should have returned nil for a period that is too long")
+	}
+}
+
+func TestFirdariaSunMissing(t *testing.T) {
+	request := validTimeLordRequest()
+	request.Radix.Points = []domain.PointPosResult{{Point: domain.Moon, LonPos: 45.0}}
+	ts := NewTimeLordService()
+	result, err := ts.Firdaria(request)
+	if err == nil {
+		t.Errorf("Firdaria should have returned an error for a radix without the Sun")
+	}
+	if result != nil {
+		t.Errorf("Firdaria should have returned nil for a radix without the Sun")
+	}
+}
+
+func TestFirdariaHappyFlow(t *testing.T) {
+	ts := NewTimeLordService()
+	result, err := ts.Firdaria(validTimeLordRequest())
+	if err != nil {
+		t.Fatalf("Firdaria returned unexpected error %v", err)
+	}
+	if len(result) != 3 || result[0].Lord != domain.Sun {
+		t.Errorf("Firdaria expected three periods starting with the Sun, got %v", result)
+	}
+}
@@ -9,6 +9,9 @@ package domain
 
 type Aspect int
 
+// FirstCustomAspect is the lowest value for a user-defined aspect, lower values are reserved for built-in aspects.
+const FirstCustomAspect Aspect = 1000
+
 const (
 	Conjunction = iota
 	Opposition
@@ -66,3 +69,62 @@ func AllAspects() []AspectData {
 		{Vigintile, "r_as_vigintile", 18.0},
 	}
 }
+
+// AspectMovement indicates if an aspect is becoming more exact (applying), less exact (separating) or if the
+// distance between the points hardly changes (stationary).
+type AspectMovement int
+
+const (
+	MovementApplying AspectMovement = iota
+	MovementSeparating
+	MovementStationary
+)
+
+type AspectMovementText struct {
+	Key    AspectMovement
+	TextId string
+}
+
+func AllAspectMovements() []AspectMovementText {
+	return []AspectMovementText{
+		{MovementApplying, "r_am_applying"},
+		{MovementSeparating, "r_am_separating"},
+		{MovementStationary, "r_am_stationary"},
+	}
+}
+
+// AspectFrame defines the coordinates that are used to measure aspects. Ecliptical uses longitude, equatorial uses
+// right ascension, horizontal uses azimuth and mundane uses the proportional position in the semi-arcs (Placidus).
+type AspectFrame int
+
+const (
+	FrameEcliptical AspectFrame = iota
+	FrameEquatorial
+	FrameHorizontal
+	FrameMundane
+)
+
+type AspectFrameText struct {
+	Key    AspectFrame
+	TextId string
+}
+
+func AllAspectFrames() []AspectFrameText {
+	return []AspectFrameText{
+		{FrameEcliptical, "r_af_ecliptical"},
+		{FrameEquatorial, "r_af_equatorial"},
+		{FrameHorizontal, "r_af_horizontal"},
+		{FrameMundane, "r_af_mundane"},
+	}
+}
+
+// OrbTable contains the precomputed orbs for all combinations of two configured points and an aspect.
+// PointIndex maps a point to its index in Orbs, the last index is used for points that are not configured.
+// Orbs is indexed by point 1, point 2 and the index of the aspect in Aspects. Distances contains the distance
+// for each aspect in Aspects. An OrbTable can be reused for any number of charts that share the same configuration.
+type OrbTable struct {
+	Aspects    []Aspect
+	Distances  []float64
+	PointIndex map[ChartPoint]int
+	Orbs       [][][]float64
+}
@@ -0,0 +1,55 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package domain
+
+// AspectPatternType is a configuration of three or more points, based on aspects or on a concentration of points.
+type AspectPatternType int
+
+const (
+	PatternGrandTrine AspectPatternType = iota
+	PatternTSquare
+	PatternGrandCross
+	PatternYod
+	PatternKite
+	PatternMysticRectangle
+	PatternStelliumSign
+	PatternStelliumHouse
+)
+
+// AspectPatternData contains presentation data for aspect patterns.
+type AspectPatternData struct {
+	Pattern AspectPatternType
+	TextId  string
+}
+
+// AllAspectPatterns returns all aspect patterns with their presentation data.
+func AllAspectPatterns() []AspectPatternData {
+	return []AspectPatternData{
+		{PatternGrandTrine, "r_ap_grandtrine"},
+		{PatternTSquare, "r_ap_tsquare"},
+		{PatternGrandCross, "r_ap_grandcross"},
+		{PatternYod, "r_ap_yod"},
+		{PatternKite, "r_ap_kite"},
+		{PatternMysticRectangle, "r_ap_mysticrectangle"},
+		{PatternStelliumSign, "r_ap_stelliumsign"},
+		{PatternStelliumHouse, "r_ap_stelliumhouse"},
+	}
+}
+
+// AspectPattern is an occurrence of a pattern in a chart. Tightness is in the range 0 .. 100, 100 is the most exact.
+// For patterns that are based on aspects, Tightness is the average exactness of the aspects in the pattern.
+// For a stellium, Tightness is based on the arc between the first and the last point, relative to the size of
+// the sign or house.
+// Sign or House refers to the location of a stellium and is only used for stelliums, House starts at 1.
+type AspectPattern struct {
+	Pattern   AspectPatternType
+	Points    []ChartPoint
+	Tightness float64
+	Sign      Sign
+	House     int
+}
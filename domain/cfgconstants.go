@@ -13,16 +13,32 @@ const (
 	CfgAspectX            = "Aspect_" // should be followed with integer for aspect
 	CfgAyanamsha          = "Ayanamsha"
 	CfgBaseOrbAspects     = "BaseOrbAspects"
+	CfgBaseOrbAspectsEqu  = "BaseOrbAspectsEquatorial"
+	CfgBaseOrbAspectsHor  = "BaseOrbAspectsHorizontal"
+	CfgBaseOrbAspectsMund = "BaseOrbAspectsMundane"
 	CfgBaseOrbMidpoints   = "BaseOrbMidpoints"
+	CfgCustomAspectX      = "CustomAspect_" // should be followed with integer for custom aspect
+	CfgExplicitOrbs       = "ExplicitOrbs"
 	CfgHouseSystem        = "HouseSystem"
 	CfgObspos             = "ObserverPosition"
+	CfgOrbAntiscia        = "OrbAntiscia"
+	CfgOrbAzimMidpoints   = "OrbAzimuthMidpoints"
 	CfgOrbDeclMidpoints   = "OrbDeclMidpoints"
+	CfgOrbLuminaryFactor  = "OrbLuminaryFactor"
+	CfgOrbMethod          = "OrbMethod"
 	CfgOrbParallels       = "OrbParallels"
 	CfgOrbPrimDir         = "OrbPrimDir"
+	CfgOrbRaMidpoints     = "OrbRaMidpoints"
 	CfgOrbSecDir          = "OrbSecDir"
 	CfgOrbSymDir          = "OrbSymDir"
 	CfgOrbTransits        = "OrbTransits"
-	CfgPointX             = "Point_" // should be followed with integer for point
+	CfgPointX             = "Point_"       // should be followed with integer for point
+	CfgPointWeightX       = "PointWeight_" // should be followed with integer for point
+	CfgPosJ2000           = "Pos_J2000"
+	CfgPosNoAberration    = "Pos_NoAberration"
+	CfgPosNoDeflection    = "Pos_NoDeflection"
+	CfgPosNoLightTime     = "Pos_NoLightTime"
+	CfgPosNoNutation      = "Pos_NoNutation"
 	CfgProgPrimDirMethod  = "Prog_PrimDirMethod"
 	CfgProgPrimDirMundane = "Prog_PrimDirMundane"
 	CfgProgPrimDirProm    = "Prog_PrimDirProm"
@@ -10,24 +10,45 @@ package domain
 import "image/color"
 
 type ConfigBasic = struct {
-	Houses   HouseSystem
-	Ayan     Ayanamsha
-	ObsPos   ObserverPosition
-	ProjType ProjectionType
-	Wheel    WheelType
+	Houses     HouseSystem
+	Ayan       Ayanamsha
+	ObsPos     ObserverPosition
+	ProjType   ProjectionType
+	Wheel      WheelType
+	PosOptions PositionOptions
 }
 
 type ConfigOrbs = struct {
-	BaseOrbAspects   float64
-	BaseOrbMidpoints float64
-	OrbDeclMidpoints float64
-	OrbParallels     float64
-	OrbTransits      float64
-	OrbSecDir        float64
-	OrbSymDir        float64
-	OrbPrimDir       float64
+	BaseOrbAspects           float64
+	BaseOrbAspectsEquatorial float64
+	BaseOrbAspectsHorizontal float64
+	BaseOrbAspectsMundane    float64
+	BaseOrbMidpoints         float64
+	OrbDeclMidpoints         float64
+	OrbRaMidpoints           float64
+	OrbAzimuthMidpoints      float64
+	OrbParallels             float64
+	OrbTransits              float64
+	OrbSecDir                float64
+	OrbSymDir                float64
+	OrbPrimDir               float64
+	OrbAntiscia              float64
+	OrbMethod                OrbMethod
+	OrbLuminaryFactor        float64
+	ExplicitOrbs             []ConfigExplicitOrb
 }
 
+// ConfigExplicitOrb defines the orb for an aspect between two points, the sequence of the points is not relevant.
+// It is only used for OrbMethodExplicit.
+type ConfigExplicitOrb = struct {
+	Point1       ChartPoint
+	Point2       ChartPoint
+	ActualAspect Aspect
+	Orb          float64
+}
+
+// ConfigAspect defines the settings for an aspect. Name and Distance are only used for custom aspects, these have a
+// value for ActualAspect >= FirstCustomAspect. Built-in aspects use the distance from AllAspects.
 type ConfigAspect = struct {
 	ActualAspect Aspect
 	IsUsed       bool
@@ -35,6 +56,8 @@ type ConfigAspect = struct {
 	OrbFactor    float64
 	Glyph        rune
 	Color        color.NRGBA
+	Name         string
+	Distance     float64
 }
 
 type ConfigPoint = struct {
@@ -43,6 +66,9 @@ type ConfigPoint = struct {
 	ShowInChart bool
 	OrbFactor   float64
 	Glyph       rune
+	// DistributionWeight is the weight of the point for the distribution over elements, modalities,
+	// hemispheres and quadrants.
+	DistributionWeight float64
 }
 
 type ConfigProg = struct {
@@ -58,10 +84,13 @@ type ConfigProg = struct {
 	SolarRelocate  bool
 }
 
+// Config contains all settings. CustomAspects contains the user-defined aspects, use them together with Aspects
+// for the calculation of aspects.
 type Config = struct {
-	Basic   ConfigBasic
-	Orbs    ConfigOrbs
-	Aspects []ConfigAspect
-	Points  []ConfigPoint
-	Prog    ConfigProg
+	Basic         ConfigBasic
+	Orbs          ConfigOrbs
+	Aspects       []ConfigAspect
+	CustomAspects []ConfigAspect
+	Points        []ConfigPoint
+	Prog          ConfigProg
 }
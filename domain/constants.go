@@ -36,6 +36,12 @@ const (
 	MaxGeoLong               = 180.0
 	MinGeoLat                = -90.0
 	MaxGeoLat                = 90.0
+	MinElevation             = -500.0 // meters
+	MaxElevation             = 10000.0
+	MinAtmPressure           = 0.0 // hPa, zero indicates that the pressure is derived from the elevation
+	MaxAtmPressure           = 1100.0
+	MinAtmTemperature        = -90.0 // degrees Celsius
+	MaxAtmTemperature        = 60.0
 	MinMultiplicationCGroups = 1
 	MaxMultiplicationCGroups = 1000
 	MinSizeCGroups           = 2
@@ -47,14 +53,22 @@ const (
 	// https://www.grc.nasa.gov/www/k-12/Numbers/Math/Mathematical_Thinking/calendar_calculations.htm
 
 	TropicalYearInDays = 365.242199074
+	// Mean length of the synodic month (new moon to new moon) in days.
+	SynodicMonthInDays = 29.530588853
 )
 
 // SE flags
 const (
 	SeflgSwieph     = 2 // use Swiss Eph
 	SeflgHelioc     = 8
+	SeflgTruePos    = 16 // no light-time correction
+	SeflgJ2000      = 32
+	SeflgNoNut      = 64
 	SeflgSpeed      = 256
+	SeflgNoGDefl    = 512  // no gravitational deflection
+	SeflgNoAberr    = 1024 // no annual aberration
 	SeflgEquatorial = 2048
+	SeflgBaryctr    = 16384 // 16 * 1024
 	SeflgTopoc      = 32768 // 32 * 1024
 	SeflgSidereal   = 65536 // 64 * 1024
 )
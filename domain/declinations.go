@@ -0,0 +1,36 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package domain
+
+// DeclinationItem is a point in a declination strip or diagram. LongEquivalent is the longitude that has the same
+// declination on the ecliptic, for out-of-bounds points the mirrored declination is used.
+type DeclinationItem struct {
+	Point          ChartPoint
+	Longitude      float64
+	Declination    float64
+	OutOfBounds    bool
+	LongEquivalent float64
+}
+
+// DeclinationCluster is a group of two or more points with parallel or contraparallel declinations, all points are
+// within the orb from each other. MinAbsDecl and MaxAbsDecl are the limits for the absolute declinations.
+// HasContra indicates that the cluster contains points at both sides of the equator.
+type DeclinationCluster struct {
+	Points     []ChartPoint
+	MinAbsDecl float64
+	MaxAbsDecl float64
+	HasContra  bool
+}
+
+// DeclinationStrip contains the data for a declination strip or a declination diagram. Items are sorted by
+// declination, from north to south. The bounds are +Obliquity and -Obliquity.
+type DeclinationStrip struct {
+	Items     []DeclinationItem
+	Obliquity float64
+	Clusters  []DeclinationCluster
+}
@@ -0,0 +1,110 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package domain
+
+// Dignity is an essential dignity or debility of a classical planet.
+type Dignity int
+
+const (
+	DignityDomicile Dignity = iota
+	DignityExaltation
+	DignityTriplicity
+	DignityTerms
+	DignityFace
+	DignityDetriment
+	DignityFall
+)
+
+// DignityData contains presentation data and the score (according to Lilly) for a dignity or debility.
+type DignityData struct {
+	Key    Dignity
+	Score  int
+	TextId string
+}
+
+// AllDignities returns all dignities and debilities with their scores.
+func AllDignities() []DignityData {
+	return []DignityData{
+		{DignityDomicile, 5, "r_dg_domicile"},
+		{DignityExaltation, 4, "r_dg_exaltation"},
+		{DignityTriplicity, 3, "r_dg_triplicity"},
+		{DignityTerms, 2, "r_dg_terms"},
+		{DignityFace, 1, "r_dg_face"},
+		{DignityDetriment, -5, "r_dg_detriment"},
+		{DignityFall, -4, "r_dg_fall"},
+	}
+}
+
+// TriplicitySystem defines the rulers of the triplicities.
+// Dorothean uses a day ruler, a night ruler and a participating ruler, Lilly and Ptolemaic only use a day ruler and
+// a night ruler. Lilly and Ptolemaic differ for the water signs: Mars rules both day and night in Lilly, Ptolemaic
+// uses Venus by day and Mars by night.
+type TriplicitySystem int
+
+const (
+	TriplicityDorothean TriplicitySystem = iota
+	TriplicityLilly
+	TriplicityPtolemaic
+)
+
+type TriplicitySystemText struct {
+	Key    TriplicitySystem
+	TextId string
+}
+
+func AllTriplicitySystems() []TriplicitySystemText {
+	return []TriplicitySystemText{
+		{TriplicityDorothean, "r_tr_dorothean"},
+		{TriplicityLilly, "r_tr_lilly"},
+		{TriplicityPtolemaic, "r_tr_ptolemaic"},
+	}
+}
+
+// TermsSystem defines the division of the signs in terms (bounds).
+type TermsSystem int
+
+const (
+	TermsEgyptian TermsSystem = iota
+	TermsPtolemaic
+)
+
+type TermsSystemText struct {
+	Key    TermsSystem
+	TextId string
+}
+
+func AllTermsSystems() []TermsSystemText {
+	return []TermsSystemText{
+		{TermsEgyptian, "r_te_egyptian"},
+		{TermsPtolemaic, "r_te_ptolemaic"},
+	}
+}
+
+// DignityScore contains the dignities and debilities of a planet and the total score.
+// Peregrine is true if the planet has no essential dignity, the score is not changed for a peregrine planet.
+type DignityScore struct {
+	Pos       SinglePosition
+	Sign      Sign
+	Dignities []Dignity
+	Score     int
+	Peregrine bool
+}
+
+// PlanetScore is the score of a classical planet, used for the calculation of an almuten.
+type PlanetScore struct {
+	Point ChartPoint
+	Score int
+}
+
+// Almuten contains the planet(s) with the highest score and the scores of all classical planets.
+// Rulers contains more than one planet if the highest score is shared.
+type Almuten struct {
+	Rulers []ChartPoint
+	Score  int
+	Scores []PlanetScore
+}
@@ -0,0 +1,78 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package domain
+
+// RulershipSystem defines the rulers of the signs. Traditional uses the seven classical planets,
+// Modern uses Uranus for Aquarius, Neptune for Pisces and Pluto for Scorpio.
+type RulershipSystem int
+
+const (
+	RulershipTraditional RulershipSystem = iota
+	RulershipModern
+)
+
+type RulershipSystemText struct {
+	Key    RulershipSystem
+	TextId string
+}
+
+func AllRulershipSystems() []RulershipSystemText {
+	return []RulershipSystemText{
+		{RulershipTraditional, "r_rs_traditional"},
+		{RulershipModern, "r_rs_modern"},
+	}
+}
+
+// ReceptionType defines the dignity that is used for a mutual reception.
+type ReceptionType int
+
+const (
+	ReceptionDomicile ReceptionType = iota
+	ReceptionExaltation
+)
+
+type ReceptionTypeText struct {
+	Key    ReceptionType
+	TextId string
+}
+
+func AllReceptionTypes() []ReceptionTypeText {
+	return []ReceptionTypeText{
+		{ReceptionDomicile, "r_rc_domicile"},
+		{ReceptionExaltation, "r_rc_exaltation"},
+	}
+}
+
+// MutualReception describes two planets that each occupy the domicile, or the exaltation, of the other planet.
+type MutualReception struct {
+	Point1    ChartPoint
+	Point2    ChartPoint
+	Reception ReceptionType
+}
+
+// DispositorNode is a planet in the dispositor graph. Dispositor is the ruler of the sign that the planet occupies,
+// Disposes contains the planets that have this planet as dispositor. Depth is the number of steps to a final
+// dispositor or to a loop, it is zero for planets that are a final dispositor or part of a loop.
+type DispositorNode struct {
+	Point      ChartPoint
+	Sign       Sign
+	Dispositor ChartPoint
+	Disposes   []ChartPoint
+	Depth      int
+}
+
+// DispositorGraph contains the dispositors for all planets, in the sequence of the chart.
+// FinalDispositors contains the planets in their own domicile, Loops contains chains of two or more planets that
+// dispose each other, each loop starts with the planet with the lowest id. The chart has a single final dispositor if
+// FinalDispositors has length 1 and Loops is empty.
+type DispositorGraph struct {
+	Nodes            []DispositorNode
+	FinalDispositors []ChartPoint
+	Loops            [][]ChartPoint
+	MutualReceptions []MutualReception
+}
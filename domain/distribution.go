@@ -0,0 +1,101 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package domain
+
+// Element is the element of a sign, the sequence starts with Aries.
+type Element int
+
+const (
+	ElementFire Element = iota
+	ElementEarth
+	ElementAir
+	ElementWater
+)
+
+type ElementText struct {
+	Key    Element
+	TextId string
+}
+
+func AllElements() []ElementText {
+	return []ElementText{
+		{ElementFire, "r_el_fire"},
+		{ElementEarth, "r_el_earth"},
+		{ElementAir, "r_el_air"},
+		{ElementWater, "r_el_water"},
+	}
+}
+
+// Modality is the modality (quadruplicity) of a sign, the sequence starts with Aries.
+type Modality int
+
+const (
+	ModalityCardinal Modality = iota
+	ModalityFixed
+	ModalityMutable
+)
+
+type ModalityText struct {
+	Key    Modality
+	TextId string
+}
+
+func AllModalities() []ModalityText {
+	return []ModalityText{
+		{ModalityCardinal, "r_md_cardinal"},
+		{ModalityFixed, "r_md_fixed"},
+		{ModalityMutable, "r_md_mutable"},
+	}
+}
+
+// ChartShape is one of the chart shapes as defined by Marc Edmund Jones.
+type ChartShape int
+
+const (
+	ShapeBundle ChartShape = iota
+	ShapeBowl
+	ShapeBucket
+	ShapeLocomotive
+	ShapeSeesaw
+	ShapeSplay
+	ShapeSplash
+)
+
+type ChartShapeText struct {
+	Key    ChartShape
+	TextId string
+}
+
+func AllChartShapes() []ChartShapeText {
+	return []ChartShapeText{
+		{ShapeBundle, "r_sh_bundle"},
+		{ShapeBowl, "r_sh_bowl"},
+		{ShapeBucket, "r_sh_bucket"},
+		{ShapeLocomotive, "r_sh_locomotive"},
+		{ShapeSeesaw, "r_sh_seesaw"},
+		{ShapeSplay, "r_sh_splay"},
+		{ShapeSplash, "r_sh_splash"},
+	}
+}
+
+// ChartDistribution summarises a chart. All values are the sum of the weights of the points, see ConfigPoint.
+// Elements and Modalities are indexed with Element and Modality. Quadrants are indexed from zero: quadrant 1
+// (ascendant to IC) has index 0. Northern is below the horizon, Southern above the horizon, Eastern is the half that
+// contains the ascendant and Western the half that contains the descendant.
+// The hemispheres and quadrants do not include the ascendant and the MC.
+// Shape is based on the longitudes of the planets Sun .. Pluto.
+type ChartDistribution struct {
+	Elements   [4]float64
+	Modalities [3]float64
+	Northern   float64
+	Southern   float64
+	Eastern    float64
+	Western    float64
+	Quadrants  [4]float64
+	Shape      ChartShape
+}
@@ -0,0 +1,57 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package domain
+
+// HarmonicComponent is the result for one harmonic in a harmonic spectrum.
+// Amplitude is the mean resultant length in the range 0.0 .. 1.0, 1.0 indicates that all positions coincide in the
+// harmonic chart. Phase is the direction of the resultant in the harmonic chart, in the range 0.0 ..< 360.0, the
+// corresponding positions in the zodiac are Phase / Harmonic + k * 360 / Harmonic.
+// RayleighZ is the statistic for the Rayleigh test (n * Amplitude^2) and PValue its approximated probability.
+type HarmonicComponent struct {
+	Harmonic  int
+	Amplitude float64
+	Phase     float64
+	RayleighZ float64
+	PValue    float64
+}
+
+// HarmonicAspect is a conjunction or an opposition between two positions in a harmonic chart.
+// Orb is the orb in the harmonic chart. RadixDistance is the shortest arc between the original positions and
+// RadixArc is the exact arc in the radix that corresponds with the conjunction or opposition, both are in the
+// range 0.0 .. 180.0. RadixAspect is the aspect with a distance equal to RadixArc, AspectFound is false if none of
+// the aspects in AllAspects has that distance.
+type HarmonicAspect struct {
+	Pos1          SinglePosition
+	Pos2          SinglePosition
+	Conjunction   bool
+	Orb           float64
+	RadixDistance float64
+	RadixArc      float64
+	RadixAspect   Aspect
+	AspectFound   bool
+}
+
+// HarmonicChart contains the positions in a harmonic chart and the conjunctions and oppositions between them.
+type HarmonicChart struct {
+	Harmonic  float64
+	Positions []SinglePosition
+	Aspects   []HarmonicAspect
+}
+
+// HarmonicMatch is a harmonic in which two points form a conjunction, with the orb in the harmonic chart.
+type HarmonicMatch struct {
+	Harmonic int
+	Orb      float64
+}
+
+// HarmonicConjunctions contains all harmonics in which two points form a conjunction.
+type HarmonicConjunctions struct {
+	Point1  ChartPoint
+	Point2  ChartPoint
+	Matches []HarmonicMatch
+}
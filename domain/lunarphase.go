@@ -0,0 +1,61 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package domain
+
+// LunarPhase is one of the eight phases of the lunation cycle, each phase covers 45 degrees of elongation.
+type LunarPhase int
+
+const (
+	PhaseNew LunarPhase = iota
+	PhaseCrescent
+	PhaseFirstQuarter
+	PhaseGibbous
+	PhaseFull
+	PhaseDisseminating
+	PhaseLastQuarter
+	PhaseBalsamic
+)
+
+// LunarPhaseData contains presentation data for lunar phases. StartAngle is the elongation of the Moon from the Sun
+// at the start of the phase.
+type LunarPhaseData struct {
+	Phase      LunarPhase
+	StartAngle float64
+	TextId     string
+}
+
+// AllLunarPhases returns all lunar phases with their presentation data.
+func AllLunarPhases() []LunarPhaseData {
+	return []LunarPhaseData{
+		{PhaseNew, 0.0, "r_lp_new"},
+		{PhaseCrescent, 45.0, "r_lp_crescent"},
+		{PhaseFirstQuarter, 90.0, "r_lp_firstquarter"},
+		{PhaseGibbous, 135.0, "r_lp_gibbous"},
+		{PhaseFull, 180.0, "r_lp_full"},
+		{PhaseDisseminating, 225.0, "r_lp_disseminating"},
+		{PhaseLastQuarter, 270.0, "r_lp_lastquarter"},
+		{PhaseBalsamic, 315.0, "r_lp_balsamic"},
+	}
+}
+
+// LunarEvent is the exact moment that the Moon reaches a specific elongation from the Sun.
+// Elongation is measured from the Sun in the direction of the zodiac and is in the range 0.0 .. 360.0.
+type LunarEvent struct {
+	Jd         float64
+	Elongation float64
+}
+
+// NatalLunarPhase describes the position of a chart in the lunation cycle.
+// JdPrenatalNewMoon and JdPrenatalFullMoon are the last new moon and the last full moon before the chart.
+type NatalLunarPhase struct {
+	Jd                 float64
+	Elongation         float64
+	Phase              LunarPhase
+	JdPrenatalNewMoon  float64
+	JdPrenatalFullMoon float64
+}
@@ -26,7 +26,7 @@ func AllMpDials() []MpDialData {
 		{Dial360, 360},
 		{Dial90, 90},
 		{Dial45, 45},
-		{Dial225, 80},
+		{Dial225, 22.5},
 	}
 }
 
@@ -45,3 +45,82 @@ type Midpoint struct {
 	Point2      SinglePosition
 	MidpointPos float64
 }
+
+// PlanetaryPictureType defines the formula for a planetary picture.
+// PictureSum: A+B-C = D, D occupies the sensitive point A+B-C.
+// PictureBalance: A+B = C+D, the sums of two pairs of points are equal.
+type PlanetaryPictureType int
+
+const (
+	PictureSum PlanetaryPictureType = iota
+	PictureBalance
+)
+
+// PlanetaryPicture contains data for a planetary picture including orb and exactness. SensitivePoint is the value
+// for A+B-C in the dial, for PictureBalance it is the value for A+B.
+type PlanetaryPicture struct {
+	PictureType    PlanetaryPictureType
+	PointA         SinglePosition
+	PointB         SinglePosition
+	PointC         SinglePosition
+	PointD         SinglePosition
+	SensitivePoint float64
+	ActualOrb      float64
+	Exactness      float64
+}
+
+// MidpointOccupation indicates the angle between a focus point and the midpoint it occupies, in the 360 degree circle.
+// Direct is a conjunction or opposition, the others are indirect: square (90), octile (45, 135) and
+// semi-octile (22.5, 67.5 ...). A half semi-octile (11.25 ...) only occurs in the dial of 22.5 degrees.
+type MidpointOccupation int
+
+const (
+	OccupationDirect MidpointOccupation = iota
+	OccupationSquare
+	OccupationOctile
+	OccupationSemiOctile
+	OccupationHalfSemiOctile
+)
+
+type MidpointOccupationData struct {
+	Key    MidpointOccupation
+	TextId string
+}
+
+func AllMidpointOccupations() []MidpointOccupationData {
+	return []MidpointOccupationData{
+		{OccupationDirect, "r_mo_direct"},
+		{OccupationSquare, "r_mo_square"},
+		{OccupationOctile, "r_mo_octile"},
+		{OccupationSemiOctile, "r_mo_semioctile"},
+		{OccupationHalfSemiOctile, "r_mo_halfsemioctile"},
+	}
+}
+
+// FramedMidpoint is an occupied midpoint that is measured in the coordinate system defined by Frame. The positions
+// in Midpoint are in that coordinate system: longitude, right ascension or azimuth.
+type FramedMidpoint struct {
+	Midpoint OccupiedMidpoint
+	Frame    CoordinateSystem
+}
+
+// TreeMidpoint is an occupied midpoint in a midpoint tree, with the type of occupation.
+type TreeMidpoint struct {
+	Midpoint   OccupiedMidpoint
+	Occupation MidpointOccupation
+}
+
+// MidpointTree contains all midpoints that are occupied by the focus point, sorted by orb.
+type MidpointTree struct {
+	FocusPoint SinglePosition
+	Midpoints  []TreeMidpoint
+}
+
+// DialSortItem is a point or a midpoint in a dial sort. For a point, Point2 is empty and IsMidpoint is false.
+// DialPos is the position in the dial.
+type DialSortItem struct {
+	Point1     SinglePosition
+	Point2     SinglePosition
+	IsMidpoint bool
+	DialPos    float64
+}
@@ -0,0 +1,86 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package domain
+
+// IngressRequest defines the search for ingresses of a point during a period.
+// The Interval is in days and can be fractional, it defines the distance between the sampled positions.
+// If the Ayanamsha is AyanNone, a tropical zodiac is used, otherwise a sidereal zodiac with the given ayanamsha.
+type IngressRequest struct {
+	Point     ChartPoint
+	JdStart   float64
+	JdEnd     float64
+	Interval  float64
+	ObsPos    ObserverPosition
+	Ayanamsha Ayanamsha
+}
+
+// SignIngress contains the exact moment that a point enters a sign. Retrograde indicates that the sign is entered
+// by retrograde movement.
+type SignIngress struct {
+	Point      ChartPoint
+	Jd         float64
+	Sign       Sign
+	Retrograde bool
+}
+
+// HouseIngress contains the exact moment that a point enters a house. Houses are counted from 1.
+type HouseIngress struct {
+	Point      ChartPoint
+	Jd         float64
+	House      int
+	Retrograde bool
+}
+
+// OobEvent contains the exact moment that a point enters or leaves the out-of-bounds area.
+// Entry is true for entering and false for leaving, North indicates the hemisphere (declination >= 0.0).
+// Obliquity is the true obliquity at the moment of the event.
+type OobEvent struct {
+	Point     ChartPoint
+	Jd        float64
+	Entry     bool
+	North     bool
+	Obliquity float64
+}
+
+// TimeLordRequest defines the search for time lords (profections and firdaria) during a period.
+// Radix is the chart for JdRadix, only the Sun and the ascendant of the radix are used.
+type TimeLordRequest struct {
+	Radix   FullChartResponse
+	JdRadix float64
+	JdStart float64
+	JdEnd   float64
+}
+
+// Profection is a profected sign with its lord, the lord is the traditional ruler of the sign.
+// Age is the age in years at the start of the profected year. Month is zero for an annual profection and 1 .. 12
+// for a monthly profection.
+type Profection struct {
+	JdStart float64
+	JdEnd   float64
+	Age     int
+	Month   int
+	Sign    Sign
+	Lord    ChartPoint
+}
+
+// FirdarPeriod is a period of the firdaria. The lord of a node period is NodeMean, SouthNode indicates the period of
+// the south node. Node periods do not have sub-periods, the other periods have seven sub-periods.
+type FirdarPeriod struct {
+	JdStart    float64
+	JdEnd      float64
+	Lord       ChartPoint
+	SouthNode  bool
+	SubPeriods []FirdarSubPeriod
+}
+
+// FirdarSubPeriod is a sub-period of the firdaria.
+type FirdarSubPeriod struct {
+	JdStart float64
+	JdEnd   float64
+	Lord    ChartPoint
+}
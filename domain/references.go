@@ -32,12 +32,15 @@ func AllCoordinateSystems() []CoordinateSystemText {
 }
 
 // ObserverPosition defines the central position for the calculations.
+// Barycentric uses the center of mass of the solar system, planetocentric uses another body as center.
 type ObserverPosition int
 
 const (
 	ObsPosGeocentric = iota
 	ObsPosTopocentric
 	ObsPosHeliocentric
+	ObsPosBarycentric
+	ObsPosPlanetocentric
 )
 
 type ObserverPosText struct {
@@ -50,6 +53,8 @@ func AllObserverPositions() []ObserverPosText {
 		{ObsPosGeocentric, "r_op_geocentric"},
 		{ObsPosTopocentric, "r_op_topocentric"},
 		{ObsPosHeliocentric, "r_op_heliocentric"},
+		{ObsPosBarycentric, "r_op_barycentric"},
+		{ObsPosPlanetocentric, "r_op_planetocentric"},
 	}
 }
 
@@ -73,6 +78,35 @@ func AllProjectionTypes() []ProjectionTypeText {
 	}
 }
 
+// PositionOptions defines the astronomical corrections for the positions of celestial points. The zero value
+// results in apparent positions for the equinox of date, this is the default.
+// NoLightTime, NoAberration and NoDeflection switch off the correction for light-time, annual aberration and
+// gravitational deflection, NoNutation uses the mean equinox instead of the true equinox, and J2000 uses the
+// equinox of J2000 instead of the equinox of date.
+type PositionOptions struct {
+	NoLightTime  bool
+	NoAberration bool
+	NoDeflection bool
+	NoNutation   bool
+	J2000        bool
+}
+
+// ApparentPositions returns the options for apparent positions, as seen by an observer.
+func ApparentPositions() PositionOptions {
+	return PositionOptions{}
+}
+
+// TruePositions returns the options for true geometric positions, without light-time, aberration and deflection.
+func TruePositions() PositionOptions {
+	return PositionOptions{NoLightTime: true, NoAberration: true, NoDeflection: true}
+}
+
+// AstrometricPositions returns the options for astrometric positions, corrected for light-time but not for
+// aberration and deflection, as used in astronomical almanacs.
+func AstrometricPositions() PositionOptions {
+	return PositionOptions{NoAberration: true, NoDeflection: true}
+}
+
 type Rating int
 
 const (
@@ -265,3 +299,32 @@ func AllPrimDirMethods() []PrimDirMethodText {
 		{MethodRegiomontanus, "r_prog_prmethod_regiomontanus"},
 	}
 }
+
+// OrbMethod defines how the orb for an aspect between two points is calculated.
+// MaxFactor uses the largest orb factor of both points, Explicit uses the orbs that are defined for a combination of
+// two points and an aspect and falls back to MaxFactor. MoietiesAverage and MoietiesSum use the moieties (half orbs)
+// of both points. Luminaries uses MaxFactor and widens the orb if a luminary or an angle is involved.
+type OrbMethod int
+
+const (
+	OrbMethodMaxFactor OrbMethod = iota
+	OrbMethodExplicit
+	OrbMethodMoietiesAverage
+	OrbMethodMoietiesSum
+	OrbMethodLuminaries
+)
+
+type OrbMethodText struct {
+	Key    OrbMethod
+	TextId string
+}
+
+func AllOrbMethods() []OrbMethodText {
+	return []OrbMethodText{
+		{OrbMethodMaxFactor, "r_om_maxfactor"},
+		{OrbMethodExplicit, "r_om_explicit"},
+		{OrbMethodMoietiesAverage, "r_om_moietiesaverage"},
+		{OrbMethodMoietiesSum, "r_om_moietiessum"},
+		{OrbMethodLuminaries, "r_om_luminaries"},
+	}
+}
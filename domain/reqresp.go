@@ -37,17 +37,27 @@ type StandardInputItem struct {
 }
 
 // PointPositionsRequest Request for the calculation of all positions for one or more points
+// Elevation is in meters above sea level and is used for topocentric positions and horizontal coordinates.
+// AtmPressure (hPa) and AtmTemperature (degrees Celsius) are used for the refraction of the apparent altitude,
+// if AtmPressure is zero the SE derives the pressure from the elevation.
+// PosOptions defines apparent, true or astrometric positions, the zero value gives apparent positions.
+// ObsCenter is the body that is used as center if ObsPos is ObsPosPlanetocentric, otherwise it is ignored.
 type PointPositionsRequest struct {
-	Points    []ChartPoint
-	JdUt      float64
-	GeoLong   float64
-	GeoLat    float64
-	Armc      float64
-	Obliquity float64
-	Coord     CoordinateSystem
-	ObsPos    ObserverPosition
-	ProjType  ProjectionType
-	Ayanamsha Ayanamsha
+	Points         []ChartPoint
+	JdUt           float64
+	GeoLong        float64
+	GeoLat         float64
+	Elevation      float64
+	AtmPressure    float64
+	AtmTemperature float64
+	Armc           float64
+	Obliquity      float64
+	Coord          CoordinateSystem
+	ObsPos         ObserverPosition
+	ObsCenter      ChartPoint
+	ProjType       ProjectionType
+	Ayanamsha      Ayanamsha
+	PosOptions     PositionOptions
 }
 
 // PointPosResult Calculated positions for a single point
@@ -73,16 +83,20 @@ type PointPosResult struct {
 // It does not have effect if the coord is radv (distance).
 // Position indicates that the position is used (true) or the speed (false).
 // If the Ayanamsha is zero, a tropical zodiac is used, otherwise a sidereal zodiac with the given ayanamsha.
+// PosOptions defines apparent, true or astrometric positions, the zero value gives apparent positions.
+// ObsCenter is the body that is used as center if ObsPos is ObsPosPlanetocentric, otherwise it is ignored.
 type PointRangeRequest struct {
-	Point     ChartPoint
-	JdStart   float64
-	JdEnd     float64
-	Interval  float64
-	Coord     CoordinateSystem
-	MainValue bool
-	Position  bool
-	ObsPos    ObserverPosition
-	Ayanamsha Ayanamsha
+	Point      ChartPoint
+	JdStart    float64
+	JdEnd      float64
+	Interval   float64
+	Coord      CoordinateSystem
+	MainValue  bool
+	Position   bool
+	ObsPos     ObserverPosition
+	ObsCenter  ChartPoint
+	Ayanamsha  Ayanamsha
+	PosOptions PositionOptions
 }
 
 // PointRangeResult calculated value for position or speed for a given date/time, to be used in a range of positions.
@@ -92,11 +106,15 @@ type PointRangeResult struct {
 }
 
 // HousePosRequest for the calculation of cusps and other mundane poiints.
+// Elevation, AtmPressure and AtmTemperature are used for the horizontal coordinates, see PointPositionsRequest.
 type HousePosRequest struct {
-	HouseSys HouseSystem
-	JdUt     float64
-	GeoLong  float64
-	GeoLat   float64
+	HouseSys       HouseSystem
+	JdUt           float64
+	GeoLong        float64
+	GeoLat         float64
+	Elevation      float64
+	AtmPressure    float64
+	AtmTemperature float64
 }
 
 // HousePosResult Calculated positions for a single cusp or other mundane point.
@@ -109,17 +127,25 @@ type HousePosResult struct {
 }
 
 // FullChartRequest for the calculation of a complete chart with positions of points and mundane positions.
+// Elevation is in meters, AtmPressure in hPa and AtmTemperature in degrees Celsius, see PointPositionsRequest.
+// PosOptions defines apparent, true or astrometric positions, the zero value gives apparent positions.
+// ObsCenter is the body that is used as center if ObsPos is ObsPosPlanetocentric, otherwise it is ignored.
 type FullChartRequest struct {
-	Points    []ChartPoint
-	HouseSys  HouseSystem
-	Ayanamsha Ayanamsha
-	CoordSys  CoordinateSystem
-	ObsPos    ObserverPosition
-	ProjType  ProjectionType
-	Jd        float64
-	Obliquity float64
-	GeoLong   float64
-	GeoLat    float64
+	Points         []ChartPoint
+	HouseSys       HouseSystem
+	Ayanamsha      Ayanamsha
+	CoordSys       CoordinateSystem
+	ObsPos         ObserverPosition
+	ObsCenter      ChartPoint
+	ProjType       ProjectionType
+	Jd             float64
+	Obliquity      float64
+	GeoLong        float64
+	GeoLat         float64
+	Elevation      float64
+	AtmPressure    float64
+	AtmTemperature float64
+	PosOptions     PositionOptions
 }
 
 // FullChartResponse contains the calculated positions for a complete chart. Use housecusps from index 1, zero is an empty placeholder.
@@ -177,6 +203,14 @@ type SinglePosition = struct {
 	Position float64
 }
 
+// PositionWithSpeed contains a single value, its daily speed and the id for the Chartpoint.
+// It supports the analysis of applying and separating aspects, the speed is typically PointPosResult.LonSpeed.
+type PositionWithSpeed = struct {
+	Id       ChartPoint
+	Position float64
+	Speed    float64
+}
+
 // DoublePosition contains two values for a chartPoiint, and the id for that chartpoint.
 // It supports combinations like longitude/declination, ra/declination and azimuth/altitude.
 type DoublePosition = struct {
@@ -194,6 +228,34 @@ type MatchedParallel = struct {
 	Parallel bool
 }
 
+// OobPosition indicates if a declination is out of bounds, i.e. if its absolute value exceeds the obliquity.
+// Excess is the number of degrees beyond the obliquity, it is zero for points that are in bounds.
+// Mirrored is the declination reflected at the boundary into the bounds, for points in bounds it equals the
+// declination.
+type OobPosition = struct {
+	Pos         SinglePosition
+	OutOfBounds bool
+	Excess      float64
+	Mirrored    float64
+}
+
+// AntisciaPosition contains a position with its antiscion (reflection over the axis Cancer/Capricorn) and its
+// contra-antiscion (reflection over the axis Aries/Libra).
+type AntisciaPosition = struct {
+	Pos             SinglePosition
+	Antiscion       float64
+	ContraAntiscion float64
+}
+
+// AntisciaContact indicates that Pos2 occupies the antiscion, or the contra-antiscion if Contra is true, of Pos1.
+// This is symmetrical: Pos1 also occupies the (contra-)antiscion of Pos2.
+type AntisciaContact = struct {
+	Pos1   SinglePosition
+	Pos2   SinglePosition
+	Contra bool
+	Orb    float64
+}
+
 // ActualAspect contains info about an actually formed aspect
 type ActualAspect = struct {
 	Pos1         SinglePosition
@@ -203,6 +265,23 @@ type ActualAspect = struct {
 	Exactness    int
 }
 
+// MovingAspect contains an actual aspect and its movement. DaysToExact is a linear estimate of the number of days
+// until the aspect is exact, it is negative for the days since the aspect was exact and zero for stationary aspects.
+type MovingAspect = struct {
+	Aspect      ActualAspect
+	Speed1      float64
+	Speed2      float64
+	Movement    AspectMovement
+	DaysToExact float64
+}
+
+// FramedAspect is an aspect that is measured in the coordinates defined by Frame. The positions in Aspect
+// are in that coordinate system: longitude, right ascension, azimuth or mundane position.
+type FramedAspect = struct {
+	Aspect ActualAspect
+	Frame  AspectFrame
+}
+
 // Country contains info about a country and its code
 type Country struct {
 	Code string
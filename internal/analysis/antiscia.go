@@ -0,0 +1,83 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"math"
+)
+
+// AntisciaCalculator calculates antiscia and contra-antiscia and the contacts between points and antiscia.
+type AntisciaCalculator interface {
+	CalcAntiscia(points []domain.SinglePosition) ([]domain.AntisciaPosition, error)
+	CalcAntisciaContacts(points []domain.SinglePosition, orb float64) ([]domain.AntisciaContact, error)
+	CalcInterAntisciaContacts(pointsA []domain.SinglePosition, pointsB []domain.SinglePosition, orb float64) ([]domain.AntisciaContact, error)
+}
+
+type AntisciaCalculation struct{}
+
+func NewAntisciaCalculation() AntisciaCalculator {
+	return AntisciaCalculation{}
+}
+
+// CalcAntiscia calculates the antiscion and the contra-antiscion for each position.
+func (ac AntisciaCalculation) CalcAntiscia(points []domain.SinglePosition) ([]domain.AntisciaPosition, error) {
+	result := make([]domain.AntisciaPosition, 0, len(points))
+	for _, point := range points {
+		result = append(result, domain.AntisciaPosition{
+			Pos:             point,
+			Antiscion:       antiscion(point.Position),
+			ContraAntiscion: contraAntiscion(point.Position),
+		})
+	}
+	return result, nil
+}
+
+// CalcAntisciaContacts finds all points that occupy the antiscion or contra-antiscion of another point.
+func (ac AntisciaCalculation) CalcAntisciaContacts(points []domain.SinglePosition, orb float64) ([]domain.AntisciaContact, error) {
+	result := make([]domain.AntisciaContact, 0)
+	for i := 0; i < len(points); i++ {
+		for j := i + 1; j < len(points); j++ {
+			result = append(result, antisciaContacts(points[i], points[j], orb)...)
+		}
+	}
+	return result, nil
+}
+
+// CalcInterAntisciaContacts finds all points in chart B that occupy the antiscion or contra-antiscion of a point in
+// chart A. Pos1 in the results refers to pointsA and Pos2 to pointsB.
+func (ac AntisciaCalculation) CalcInterAntisciaContacts(pointsA []domain.SinglePosition, pointsB []domain.SinglePosition, orb float64) ([]domain.AntisciaContact, error) {
+	result := make([]domain.AntisciaContact, 0)
+	for _, pointA := range pointsA {
+		for _, pointB := range pointsB {
+			result = append(result, antisciaContacts(pointA, pointB, orb)...)
+		}
+	}
+	return result, nil
+}
+
+func antisciaContacts(point1, point2 domain.SinglePosition, orb float64) []domain.AntisciaContact {
+	contacts := make([]domain.AntisciaContact, 0)
+	if actOrb := shortestArc(antiscion(point1.Position), point2.Position); actOrb <= orb {
+		contacts = append(contacts, domain.AntisciaContact{Pos1: point1, Pos2: point2, Contra: false, Orb: actOrb})
+	}
+	if actOrb := shortestArc(contraAntiscion(point1.Position), point2.Position); actOrb <= orb {
+		contacts = append(contacts, domain.AntisciaContact{Pos1: point1, Pos2: point2, Contra: true, Orb: actOrb})
+	}
+	return contacts
+}
+
+// antiscion returns the reflection of a position over the axis 0 Cancer - 0 Capricorn.
+func antiscion(position float64) float64 {
+	return math.Mod(540.0-position, 360.0)
+}
+
+// contraAntiscion returns the reflection of a position over the axis 0 Aries - 0 Libra.
+func contraAntiscion(position float64) float64 {
+	return math.Mod(360.0-position, 360.0)
+}
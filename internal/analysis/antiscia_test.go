@@ -0,0 +1,72 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+func TestCalcAntiscia(t *testing.T) {
+	points := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 250.0},
+		{Id: domain.Mars, Position: 0.0},
+	}
+	expected := [][2]float64{{170.0, 350.0}, {290.0, 110.0}, {180.0, 0.0}}
+	ac := AntisciaCalculation{}
+	result, err := ac.CalcAntiscia(points)
+	if err != nil {
+		t.Fatalf("CalcAntiscia returned unexpected error %v", err)
+	}
+	for i, exp := range expected {
+		if math.Abs(result[i].Antiscion-exp[0]) > delta || math.Abs(result[i].ContraAntiscion-exp[1]) > delta {
+			t.Errorf("CalcAntiscia expected %f and %f at index %d, got %v", exp[0], exp[1], i, result[i])
+		}
+	}
+}
+
+func TestCalcAntisciaContacts(t *testing.T) {
+	points := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 170.5},  // antiscion of Sun
+		{Id: domain.Mars, Position: 349.2},  // contra-antiscion of Sun
+		{Id: domain.Venus, Position: 100.0}, // no contacts
+	}
+	ac := AntisciaCalculation{}
+	result, err := ac.CalcAntisciaContacts(points, 1.0)
+	if err != nil {
+		t.Fatalf("CalcAntisciaContacts returned unexpected error %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("CalcAntisciaContacts expected 2 contacts, got %d: %v", len(result), result)
+	}
+	if result[0].Pos2.Id != domain.Moon || result[0].Contra || math.Abs(result[0].Orb-0.5) > delta {
+		t.Errorf("CalcAntisciaContacts expected antiscion Sun - Moon with orb 0.5, got %v", result[0])
+	}
+	if result[1].Pos2.Id != domain.Mars || !result[1].Contra || math.Abs(result[1].Orb-0.8) > delta {
+		t.Errorf("CalcAntisciaContacts expected contra-antiscion Sun - Mars with orb 0.8, got %v", result[1])
+	}
+}
+
+func TestCalcInterAntisciaContacts(t *testing.T) {
+	pointsA := []domain.SinglePosition{{Id: domain.Sun, Position: 95.0}}
+	pointsB := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 84.6},
+		{Id: domain.Moon, Position: 95.0},
+	}
+	ac := AntisciaCalculation{}
+	result, err := ac.CalcInterAntisciaContacts(pointsA, pointsB, 1.0)
+	if err != nil {
+		t.Fatalf("CalcInterAntisciaContacts returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Pos2.Id != domain.Sun || math.Abs(result[0].Orb-0.4) > delta {
+		t.Errorf("CalcInterAntisciaContacts expected antiscion with Sun of chart B, got %v", result)
+	}
+}
@@ -0,0 +1,345 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"math"
+)
+
+const SignSize = 30.0
+
+// AspectPatternCalculator finds patterns in a chart, using the actual aspects and the positions of the points.
+type AspectPatternCalculator interface {
+	CalcPatterns(aspects []domain.ActualAspect,
+		points []domain.SinglePosition,
+		cusps []float64,
+		minStellium int) ([]domain.AspectPattern, error)
+}
+
+type AspectPatternCalculation struct{}
+
+func NewAspectPatternCalculation() AspectPatternCalculator {
+	return AspectPatternCalculation{}
+}
+
+// pointPair is the key for the aspects between two points, the lowest id is always in p1.
+type pointPair struct {
+	p1 domain.ChartPoint
+	p2 domain.ChartPoint
+}
+
+// requirement is an aspect between two points that should be present in a pattern.
+type requirement struct {
+	p1     domain.ChartPoint
+	p2     domain.ChartPoint
+	aspect domain.Aspect
+}
+
+// aspectLookup contains the exactness of all aspects, per pair of points.
+type aspectLookup map[pointPair]map[domain.Aspect]int
+
+func newPointPair(p1, p2 domain.ChartPoint) pointPair {
+	if p1 > p2 {
+		return pointPair{p2, p1}
+	}
+	return pointPair{p1, p2}
+}
+
+// exactness returns the exactness of an aspect between two points and false if the aspect does not exist.
+func (al aspectLookup) exactness(p1, p2 domain.ChartPoint, aspect domain.Aspect) (int, bool) {
+	exactness, found := al[newPointPair(p1, p2)][aspect]
+	return exactness, found
+}
+
+// CalcPatterns returns all patterns, grouped by type of pattern. Patterns can be part of other patterns: a grand
+// cross also results in four T-squares and a kite also results in a grand trine.
+// For a T-square and a yod, the first point is the apex. For a kite, the first point is the point that is in
+// opposition with one of the points of the grand trine.
+// Stelliums by house are only calculated if cusps are given, they start with the cusp of the first house.
+// PRE minStellium >= 3
+// POST no errors -> returns patterns. Errors: returns nil and error
+func (apc AspectPatternCalculation) CalcPatterns(aspects []domain.ActualAspect,
+	points []domain.SinglePosition,
+	cusps []float64,
+	minStellium int) ([]domain.AspectPattern, error) {
+
+	lookup := make(aspectLookup)
+	for _, aspect := range aspects {
+		pair := newPointPair(aspect.Pos1.Id, aspect.Pos2.Id)
+		if lookup[pair] == nil {
+			lookup[pair] = make(map[domain.Aspect]int)
+		}
+		lookup[pair][aspect.ActualAspect] = aspect.Exactness
+	}
+	ids := make([]domain.ChartPoint, 0, len(points))
+	for _, point := range points {
+		ids = append(ids, point.Id)
+	}
+	patterns := make([]domain.AspectPattern, 0)
+	patterns = append(patterns, findGrandTrines(lookup, ids)...)
+	patterns = append(patterns, findTSquares(lookup, ids)...)
+	patterns = append(patterns, findGrandCrosses(lookup, ids)...)
+	patterns = append(patterns, findYods(lookup, ids)...)
+	patterns = append(patterns, findKites(lookup, ids)...)
+	patterns = append(patterns, findMysticRectangles(lookup, ids)...)
+	patterns = append(patterns, findSignStelliums(points, minStellium)...)
+	if len(cusps) > 0 {
+		patterns = append(patterns, findHouseStelliums(points, cusps, minStellium)...)
+	}
+	return patterns, nil
+}
+
+// match checks a set of required aspects, each requirement consists of two points and an aspect.
+// Returns the average exactness and true if all aspects exist.
+func (al aspectLookup) match(requirements []requirement) (float64, bool) {
+	total := 0
+	for _, req := range requirements {
+		exactness, found := al.exactness(req.p1, req.p2, req.aspect)
+		if !found {
+			return 0.0, false
+		}
+		total += exactness
+	}
+	return float64(total) / float64(len(requirements)), true
+}
+
+func findGrandTrines(lookup aspectLookup, ids []domain.ChartPoint) []domain.AspectPattern {
+	patterns := make([]domain.AspectPattern, 0)
+	forAllTriples(ids, func(a, b, c domain.ChartPoint) {
+		if tightness, ok := lookup.match(grandTrine(a, b, c)); ok {
+			patterns = append(patterns, newPattern(domain.PatternGrandTrine, tightness, a, b, c))
+		}
+	})
+	return patterns
+}
+
+func findTSquares(lookup aspectLookup, ids []domain.ChartPoint) []domain.AspectPattern {
+	patterns := make([]domain.AspectPattern, 0)
+	forAllTriples(ids, func(a, b, c domain.ChartPoint) {
+		for _, tr := range rotations(a, b, c) {
+			apex, p1, p2 := tr[0], tr[1], tr[2]
+			if tightness, ok := lookup.match([]requirement{
+				{p1, p2, domain.Opposition},
+				{apex, p1, domain.Square},
+				{apex, p2, domain.Square},
+			}); ok {
+				patterns = append(patterns, newPattern(domain.PatternTSquare, tightness, apex, p1, p2))
+			}
+		}
+	})
+	return patterns
+}
+
+func findYods(lookup aspectLookup, ids []domain.ChartPoint) []domain.AspectPattern {
+	patterns := make([]domain.AspectPattern, 0)
+	forAllTriples(ids, func(a, b, c domain.ChartPoint) {
+		for _, tr := range rotations(a, b, c) {
+			apex, p1, p2 := tr[0], tr[1], tr[2]
+			if tightness, ok := lookup.match([]requirement{
+				{p1, p2, domain.Sextile},
+				{apex, p1, domain.Inconjunct},
+				{apex, p2, domain.Inconjunct},
+			}); ok {
+				patterns = append(patterns, newPattern(domain.PatternYod, tightness, apex, p1, p2))
+			}
+		}
+	})
+	return patterns
+}
+
+func findGrandCrosses(lookup aspectLookup, ids []domain.ChartPoint) []domain.AspectPattern {
+	patterns := make([]domain.AspectPattern, 0)
+	forAllQuadruples(ids, func(a, b, c, d domain.ChartPoint) {
+		for _, op := range oppositionPairings(a, b, c, d) {
+			x1, x2, y1, y2 := op[0], op[1], op[2], op[3]
+			if tightness, ok := lookup.match([]requirement{
+				{x1, x2, domain.Opposition},
+				{y1, y2, domain.Opposition},
+				{x1, y1, domain.Square},
+				{x1, y2, domain.Square},
+				{x2, y1, domain.Square},
+				{x2, y2, domain.Square},
+			}); ok {
+				patterns = append(patterns, newPattern(domain.PatternGrandCross, tightness, a, b, c, d))
+				return
+			}
+		}
+	})
+	return patterns
+}
+
+func findMysticRectangles(lookup aspectLookup, ids []domain.ChartPoint) []domain.AspectPattern {
+	patterns := make([]domain.AspectPattern, 0)
+	forAllQuadruples(ids, func(a, b, c, d domain.ChartPoint) {
+		for _, op := range oppositionPairings(a, b, c, d) {
+			x1, x2, y1, y2 := op[0], op[1], op[2], op[3]
+			for _, swap := range []bool{false, true} {
+				first, second := y1, y2
+				if swap {
+					first, second = y2, y1
+				}
+				if tightness, ok := lookup.match([]requirement{
+					{x1, x2, domain.Opposition},
+					{first, second, domain.Opposition},
+					{x1, first, domain.Trine},
+					{x1, second, domain.Sextile},
+					{x2, first, domain.Sextile},
+					{x2, second, domain.Trine},
+				}); ok {
+					patterns = append(patterns, newPattern(domain.PatternMysticRectangle, tightness, a, b, c, d))
+					return
+				}
+			}
+		}
+	})
+	return patterns
+}
+
+func findKites(lookup aspectLookup, ids []domain.ChartPoint) []domain.AspectPattern {
+	patterns := make([]domain.AspectPattern, 0)
+	forAllQuadruples(ids, func(a, b, c, d domain.ChartPoint) {
+		quad := []domain.ChartPoint{a, b, c, d}
+		for i, focus := range quad {
+			others := make([]domain.ChartPoint, 0, 3)
+			for j, p := range quad {
+				if j != i {
+					others = append(others, p)
+				}
+			}
+			for _, tr := range rotations(others[0], others[1], others[2]) {
+				opposite, p1, p2 := tr[0], tr[1], tr[2]
+				reqs := append(grandTrine(opposite, p1, p2),
+					requirement{focus, opposite, domain.Opposition},
+					requirement{focus, p1, domain.Sextile},
+					requirement{focus, p2, domain.Sextile})
+				if tightness, ok := lookup.match(reqs); ok {
+					patterns = append(patterns, newPattern(domain.PatternKite, tightness, focus, opposite, p1, p2))
+				}
+			}
+		}
+	})
+	return patterns
+}
+
+// findSignStelliums finds groups of at least minStellium points in the same sign.
+func findSignStelliums(points []domain.SinglePosition, minStellium int) []domain.AspectPattern {
+	patterns := make([]domain.AspectPattern, 0)
+	for _, sign := range domain.AllSigns() {
+		members := make([]domain.SinglePosition, 0)
+		for _, point := range points {
+			if int(point.Position/SignSize) == int(sign.Key) {
+				members = append(members, point)
+			}
+		}
+		if len(members) < minStellium {
+			continue
+		}
+		pattern := stellium(domain.PatternStelliumSign, members, float64(sign.Key)*SignSize, SignSize)
+		pattern.Sign = sign.Key
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// findHouseStelliums finds groups of at least minStellium points in the same house.
+func findHouseStelliums(points []domain.SinglePosition, cusps []float64, minStellium int) []domain.AspectPattern {
+	patterns := make([]domain.AspectPattern, 0)
+	for i := range cusps {
+		start := cusps[i]
+		houseSize := arcForward(start, cusps[(i+1)%len(cusps)])
+		members := make([]domain.SinglePosition, 0)
+		for _, point := range points {
+			if arcForward(start, point.Position) < houseSize {
+				members = append(members, point)
+			}
+		}
+		if len(members) < minStellium {
+			continue
+		}
+		pattern := stellium(domain.PatternStelliumHouse, members, start, houseSize)
+		pattern.House = i + 1
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// stellium constructs a stellium, the tightness is based on the arc between the first and last point in the area.
+func stellium(patternType domain.AspectPatternType, members []domain.SinglePosition, start, size float64) domain.AspectPattern {
+	minArc, maxArc := size, 0.0
+	ids := make([]domain.ChartPoint, 0, len(members))
+	for _, member := range members {
+		arc := arcForward(start, member.Position)
+		minArc = math.Min(minArc, arc)
+		maxArc = math.Max(maxArc, arc)
+		ids = append(ids, member.Id)
+	}
+	return domain.AspectPattern{
+		Pattern:   patternType,
+		Points:    ids,
+		Tightness: 100.0 * (1.0 - (maxArc-minArc)/size),
+	}
+}
+
+// arcForward returns the arc from pos1 to pos2, in the direction of the zodiac, in the range 0.0 ..< 360.0.
+func arcForward(pos1, pos2 float64) float64 {
+	arc := math.Mod(pos2-pos1, 360.0)
+	if arc < 0.0 {
+		arc += 360.0
+	}
+	return arc
+}
+
+func grandTrine(a, b, c domain.ChartPoint) []requirement {
+	return []requirement{
+		{a, b, domain.Trine},
+		{a, c, domain.Trine},
+		{b, c, domain.Trine},
+	}
+}
+
+func newPattern(patternType domain.AspectPatternType, tightness float64, ids ...domain.ChartPoint) domain.AspectPattern {
+	return domain.AspectPattern{
+		Pattern:   patternType,
+		Points:    ids,
+		Tightness: tightness,
+	}
+}
+
+// rotations returns the three triples that each start with a different point.
+func rotations(a, b, c domain.ChartPoint) [][3]domain.ChartPoint {
+	return [][3]domain.ChartPoint{{a, b, c}, {b, a, c}, {c, a, b}}
+}
+
+// oppositionPairings returns the three ways to divide four points into two pairs, the first two points form
+// a pair and the last two points form a pair.
+func oppositionPairings(a, b, c, d domain.ChartPoint) [][4]domain.ChartPoint {
+	return [][4]domain.ChartPoint{{a, b, c, d}, {a, c, b, d}, {a, d, b, c}}
+}
+
+func forAllTriples(ids []domain.ChartPoint, handle func(a, b, c domain.ChartPoint)) {
+	for i := 0; i < len(ids); i++ {
+		for j := i + 1; j < len(ids); j++ {
+			for k := j + 1; k < len(ids); k++ {
+				handle(ids[i], ids[j], ids[k])
+			}
+		}
+	}
+}
+
+func forAllQuadruples(ids []domain.ChartPoint, handle func(a, b, c, d domain.ChartPoint)) {
+	for i := 0; i < len(ids); i++ {
+		for j := i + 1; j < len(ids); j++ {
+			for k := j + 1; k < len(ids); k++ {
+				for l := k + 1; l < len(ids); l++ {
+					handle(ids[i], ids[j], ids[k], ids[l])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+func TestCalcPatternsKite(t *testing.T) {
+	aspects := []domain.ActualAspect{
+		actualAspect(0, 1, domain.Trine, 90),
+		actualAspect(0, 2, domain.Trine, 80),
+		actualAspect(1, 2, domain.Trine, 70),
+		actualAspect(3, 0, domain.Opposition, 60),
+		actualAspect(3, 1, domain.Sextile, 50),
+		actualAspect(3, 2, domain.Sextile, 50),
+	}
+	points := positions(10.0, 130.0, 250.0, 190.0)
+	apc := AspectPatternCalculation{}
+	result, err := apc.CalcPatterns(aspects, points, []float64{}, 3)
+	if err != nil {
+		t.Fatalf("CalcPatterns returned unexpected error %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("CalcPatterns expected 2 patterns, got %d: %v", len(result), result)
+	}
+	if result[0].Pattern != domain.PatternGrandTrine || math.Abs(result[0].Tightness-80.0) > 1e-8 {
+		t.Errorf("CalcPatterns expected grand trine with tightness 80, got %v", result[0])
+	}
+	if result[1].Pattern != domain.PatternKite || math.Abs(result[1].Tightness-400.0/6.0) > 1e-8 {
+		t.Errorf("CalcPatterns expected kite with tightness 66.67, got %v", result[1])
+	}
+	if !samePoints(result[1].Points, []domain.ChartPoint{3, 0, 1, 2}) {
+		t.Errorf("CalcPatterns expected kite with points 3, 0, 1, 2, got %v", result[1].Points)
+	}
+}
+
+func TestCalcPatternsGrandCross(t *testing.T) {
+	aspects := []domain.ActualAspect{
+		actualAspect(0, 1, domain.Square, 100),
+		actualAspect(0, 2, domain.Opposition, 100),
+		actualAspect(0, 3, domain.Square, 100),
+		actualAspect(1, 2, domain.Square, 100),
+		actualAspect(1, 3, domain.Opposition, 100),
+		actualAspect(2, 3, domain.Square, 100),
+	}
+	points := positions(10.0, 100.0, 190.0, 280.0)
+	apc := AspectPatternCalculation{}
+	result, err := apc.CalcPatterns(aspects, points, []float64{}, 3)
+	if err != nil {
+		t.Fatalf("CalcPatterns returned unexpected error %v", err)
+	}
+	tSquares, grandCrosses := 0, 0
+	for _, pattern := range result {
+		switch pattern.Pattern {
+		case domain.PatternTSquare:
+			tSquares++
+		case domain.PatternGrandCross:
+			grandCrosses++
+		default:
+			t.Errorf("CalcPatterns found unexpected pattern %v", pattern)
+		}
+	}
+	if tSquares != 4 || grandCrosses != 1 {
+		t.Errorf("CalcPatterns expected 4 T-squares and 1 grand cross, got %d and %d", tSquares, grandCrosses)
+	}
+}
+
+func TestCalcPatternsYod(t *testing.T) {
+	aspects := []domain.ActualAspect{
+		actualAspect(0, 1, domain.Sextile, 80),
+		actualAspect(0, 2, domain.Inconjunct, 60),
+		actualAspect(1, 2, domain.Inconjunct, 40),
+	}
+	points := positions(10.0, 70.0, 190.0)
+	apc := AspectPatternCalculation{}
+	result, err := apc.CalcPatterns(aspects, points, []float64{}, 3)
+	if err != nil {
+		t.Fatalf("CalcPatterns returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Pattern != domain.PatternYod {
+		t.Fatalf("CalcPatterns expected 1 yod, got %v", result)
+	}
+	if !samePoints(result[0].Points, []domain.ChartPoint{2, 0, 1}) || math.Abs(result[0].Tightness-60.0) > 1e-8 {
+		t.Errorf("CalcPatterns expected yod with apex 2 and tightness 60, got %v", result[0])
+	}
+}
+
+func TestCalcPatternsMysticRectangle(t *testing.T) {
+	aspects := []domain.ActualAspect{
+		actualAspect(0, 1, domain.Opposition, 100),
+		actualAspect(2, 3, domain.Opposition, 100),
+		actualAspect(0, 2, domain.Trine, 100),
+		actualAspect(0, 3, domain.Sextile, 100),
+		actualAspect(1, 2, domain.Sextile, 100),
+		actualAspect(1, 3, domain.Trine, 100),
+	}
+	points := positions(10.0, 190.0, 130.0, 310.0)
+	apc := AspectPatternCalculation{}
+	result, err := apc.CalcPatterns(aspects, points, []float64{}, 3)
+	if err != nil {
+		t.Fatalf("CalcPatterns returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Pattern != domain.PatternMysticRectangle {
+		t.Errorf("CalcPatterns expected 1 mystic rectangle, got %v", result)
+	}
+}
+
+func TestCalcPatternsStelliums(t *testing.T) {
+	points := positions(10.0, 15.0, 25.0, 40.0)
+	cusps := []float64{5.0, 35.0, 65.0, 95.0, 125.0, 155.0, 185.0, 215.0, 245.0, 275.0, 305.0, 335.0}
+	apc := AspectPatternCalculation{}
+	result, err := apc.CalcPatterns([]domain.ActualAspect{}, points, cusps, 3)
+	if err != nil {
+		t.Fatalf("CalcPatterns returned unexpected error %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("CalcPatterns expected 2 stelliums, got %v", result)
+	}
+	if result[0].Pattern != domain.PatternStelliumSign || result[0].Sign != domain.Aries ||
+		math.Abs(result[0].Tightness-50.0) > 1e-8 || len(result[0].Points) != 3 {
+		t.Errorf("CalcPatterns expected stellium in Aries with tightness 50, got %v", result[0])
+	}
+	if result[1].Pattern != domain.PatternStelliumHouse || result[1].House != 1 ||
+		math.Abs(result[1].Tightness-50.0) > 1e-8 || len(result[1].Points) != 3 {
+		t.Errorf("CalcPatterns expected stellium in house 1 with tightness 50, got %v", result[1])
+	}
+}
+
+func TestCalcPatternsStelliumAcrossZeroAries(t *testing.T) {
+	points := positions(350.0, 355.0, 2.0, 8.0)
+	cusps := []float64{340.0, 10.0, 40.0, 70.0, 100.0, 130.0, 160.0, 190.0, 220.0, 250.0, 280.0, 310.0}
+	apc := AspectPatternCalculation{}
+	result, err := apc.CalcPatterns([]domain.ActualAspect{}, points, cusps, 4)
+	if err != nil {
+		t.Fatalf("CalcPatterns returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Pattern != domain.PatternStelliumHouse || result[0].House != 1 {
+		t.Fatalf("CalcPatterns expected 1 stellium in house 1, got %v", result)
+	}
+	expected := 100.0 * (1.0 - 18.0/30.0)
+	if math.Abs(result[0].Tightness-expected) > 1e-8 {
+		t.Errorf("CalcPatterns expected tightness %f, got %f", expected, result[0].Tightness)
+	}
+}
+
+func actualAspect(id1, id2 domain.ChartPoint, aspect domain.Aspect, exactness int) domain.ActualAspect {
+	return domain.ActualAspect{
+		Pos1:         domain.SinglePosition{Id: id1},
+		Pos2:         domain.SinglePosition{Id: id2},
+		ActualAspect: aspect,
+		Exactness:    exactness,
+	}
+}
+
+// positions creates single positions, the ids are the indexes of the values.
+func positions(values ...float64) []domain.SinglePosition {
+	result := make([]domain.SinglePosition, 0, len(values))
+	for i, value := range values {
+		result = append(result, domain.SinglePosition{Id: domain.ChartPoint(i), Position: value})
+	}
+	return result
+}
+
+func samePoints(actual, expected []domain.ChartPoint) bool {
+	if len(actual) != len(expected) {
+		return false
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
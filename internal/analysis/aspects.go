@@ -9,6 +9,9 @@ package analysis
 
 import (
 	"enigma-ar/domain"
+	"enigma-ar/internal/calc/mathextra"
+	"errors"
+	"fmt"
 	"math"
 )
 
@@ -17,82 +20,257 @@ type AspectsCalculator interface {
 		aspects []domain.Aspect,
 		cfgPoints []domain.ConfigPoint,
 		cfgAspects []domain.ConfigAspect,
-		baseOrb float64) ([]domain.ActualAspect, error)
+		orbs domain.ConfigOrbs) ([]domain.ActualAspect, error)
+	CalcMovingAspects(points []domain.PositionWithSpeed,
+		aspects []domain.Aspect,
+		cfgPoints []domain.ConfigPoint,
+		cfgAspects []domain.ConfigAspect,
+		orbs domain.ConfigOrbs) ([]domain.MovingAspect, error)
+	CalcInterAspects(pointsA []domain.SinglePosition,
+		pointsB []domain.SinglePosition,
+		aspects []domain.Aspect,
+		cfgPoints []domain.ConfigPoint,
+		cfgAspects []domain.ConfigAspect,
+		orbs domain.ConfigOrbs) ([]domain.ActualAspect, error)
+	CalcFramedAspects(points []domain.PointPosResult,
+		frame domain.AspectFrame,
+		armc float64,
+		geoLat float64,
+		aspects []domain.Aspect,
+		cfgPoints []domain.ConfigPoint,
+		cfgAspects []domain.ConfigAspect,
+		orbs domain.ConfigOrbs) ([]domain.FramedAspect, error)
+	CreateOrbTable(aspects []domain.Aspect,
+		cfgPoints []domain.ConfigPoint,
+		cfgAspects []domain.ConfigAspect,
+		orbCfg domain.ConfigOrbs) domain.OrbTable
+	CalcAspectsWithOrbTable(points []domain.SinglePosition, table *domain.OrbTable) ([]domain.ActualAspect, error)
+	CalcInterAspectsWithOrbTable(pointsA []domain.SinglePosition,
+		pointsB []domain.SinglePosition,
+		table *domain.OrbTable) ([]domain.ActualAspect, error)
 }
 
+// MinRelativeSpeed is the minimal difference in speed, in degrees per day, for an aspect that is not stationary.
+const MinRelativeSpeed = 0.0001
+
 type AspectsCalculation struct{}
 
 func NewAspectsCalculation() AspectsCalculator {
 	return AspectsCalculation{}
 }
 
-// CalcAspects returns the actual aspects
+// CalcAspects returns the actual aspects, the orbs are based on the base orb for aspects and the orb method in orbs.
+// Use CreateOrbTable and CalcAspectsWithOrbTable to reuse the orbs for multiple charts.
+// Custom aspects are supported, their distance is taken from cfgAspects.
 func (ac AspectsCalculation) CalcAspects(points []domain.SinglePosition,
 	aspects []domain.Aspect,
 	cfgPoints []domain.ConfigPoint,
 	cfgAspects []domain.ConfigAspect,
-	baseOrb float64) ([]domain.ActualAspect, error) {
+	orbs domain.ConfigOrbs) ([]domain.ActualAspect, error) {
 
-	const (
-		FullCircle = 360.0
-		Zero       = 0.0
-	)
+	table := ac.CreateOrbTable(aspects, cfgPoints, cfgAspects, orbs)
+	return ac.CalcAspectsWithOrbTable(points, &table)
+}
 
+// CalcAspectsWithOrbTable returns the actual aspects, using a precomputed orb table.
+func (ac AspectsCalculation) CalcAspectsWithOrbTable(points []domain.SinglePosition, table *domain.OrbTable) ([]domain.ActualAspect, error) {
 	actualAspects := make([]domain.ActualAspect, 0)
-
-	var currentCfgOrb, aspectDistance, delta, delta1, delta2 float64
-	var exactness int
 	for i := 0; i < len(points); i++ {
 		for j := i + 1; j < len(points); j++ {
-			// calculate distances
-			distance1 := math.Abs(points[i].Position - points[j].Position)
-			if distance1 < Zero {
-				distance1 += FullCircle
-			}
-			distance2 := FullCircle - distance1
-			for _, aspect := range aspects {
-				// define orb
-				var factor1, factor2 float64
-				var aspectFactor float64
-				for _, cfgPoint := range cfgPoints {
-					if cfgPoint.ActualPoint == points[i].Id {
-						factor1 = cfgPoint.OrbFactor
-					}
-					if cfgPoint.ActualPoint == points[j].Id {
-						factor2 = cfgPoint.OrbFactor
-					}
-				}
-				for _, cfgAspect := range cfgAspects {
-					if aspect == cfgAspect.ActualAspect {
-						aspectFactor = cfgAspect.OrbFactor
-						aspectDistance = domain.AllAspects()[aspect].Distance
-					}
-				}
-				currentCfgOrb = ((math.Max(factor1, factor2) * aspectFactor) / 10000) * baseOrb
-				// check for match
-				delta1 = math.Abs(distance1 - aspectDistance)
-				if delta1 < Zero {
-					delta1 += FullCircle
-				}
-				delta2 = math.Abs(distance2 - aspectDistance)
-				if delta2 < Zero {
-					delta2 += FullCircle
-				}
-				delta = math.Min(delta1, delta2)
-
-				if delta <= currentCfgOrb {
-					// match
-					exactness = 100 - int((delta/currentCfgOrb)*100)
-					actualAspects = append(actualAspects, domain.ActualAspect{
-						Pos1:         points[i],
-						Pos2:         points[j],
-						ActualAspect: aspect,
-						ActualOrb:    delta,
-						Exactness:    exactness,
-					})
-				}
-			}
+			actualAspects = append(actualAspects, aspectsForPair(points[i], points[j], table)...)
 		}
 	}
 	return actualAspects, nil
 }
+
+// CalcInterAspects returns the actual aspects between the points of two charts, it does not check for aspects
+// within the same chart. Pos1 in the results refers to pointsA and Pos2 to pointsB.
+func (ac AspectsCalculation) CalcInterAspects(pointsA []domain.SinglePosition,
+	pointsB []domain.SinglePosition,
+	aspects []domain.Aspect,
+	cfgPoints []domain.ConfigPoint,
+	cfgAspects []domain.ConfigAspect,
+	orbs domain.ConfigOrbs) ([]domain.ActualAspect, error) {
+	table := ac.CreateOrbTable(aspects, cfgPoints, cfgAspects, orbs)
+	return ac.CalcInterAspectsWithOrbTable(pointsA, pointsB, &table)
+}
+
+// CalcInterAspectsWithOrbTable returns the actual aspects between the points of two charts, using a precomputed
+// orb table. Pos1 in the results refers to pointsA and Pos2 to pointsB.
+func (ac AspectsCalculation) CalcInterAspectsWithOrbTable(pointsA []domain.SinglePosition,
+	pointsB []domain.SinglePosition,
+	table *domain.OrbTable) ([]domain.ActualAspect, error) {
+	actualAspects := make([]domain.ActualAspect, 0)
+	for _, pointA := range pointsA {
+		for _, pointB := range pointsB {
+			actualAspects = append(actualAspects, aspectsForPair(pointA, pointB, table)...)
+		}
+	}
+	return actualAspects, nil
+}
+
+// CalcFramedAspects returns the actual aspects, measured in the given frame. Ecliptical uses longitude, equatorial
+// uses right ascension, horizontal uses azimuth and mundane uses the proportional position in the semi-arcs.
+// The base orb for the frame is taken from orbs, the orb method is the same for all frames.
+// Armc and geoLat are only used for the mundane frame.
+func (ac AspectsCalculation) CalcFramedAspects(points []domain.PointPosResult,
+	frame domain.AspectFrame,
+	armc float64,
+	geoLat float64,
+	aspects []domain.Aspect,
+	cfgPoints []domain.ConfigPoint,
+	cfgAspects []domain.ConfigAspect,
+	orbs domain.ConfigOrbs) ([]domain.FramedAspect, error) {
+	frameOrbs := orbs
+	switch frame {
+	case domain.FrameEquatorial:
+		frameOrbs.BaseOrbAspects = orbs.BaseOrbAspectsEquatorial
+	case domain.FrameHorizontal:
+		frameOrbs.BaseOrbAspects = orbs.BaseOrbAspectsHorizontal
+	case domain.FrameMundane:
+		frameOrbs.BaseOrbAspects = orbs.BaseOrbAspectsMundane
+	}
+	positions := make([]domain.SinglePosition, 0, len(points))
+	for _, point := range points {
+		var pos float64
+		switch frame {
+		case domain.FrameEcliptical:
+			pos = point.LonPos
+		case domain.FrameEquatorial:
+			pos = point.RaPos
+		case domain.FrameHorizontal:
+			pos = point.AzimPos
+		case domain.FrameMundane:
+			mundanePos, err := mundanePosition(point.RaPos, point.DeclPos, armc, geoLat)
+			if err != nil {
+				return nil, fmt.Errorf("no mundane position for point %d: %w", point.Point, err)
+			}
+			pos = mundanePos
+		default:
+			return nil, fmt.Errorf("unknown frame %d", frame)
+		}
+		positions = append(positions, domain.SinglePosition{Id: point.Point, Position: pos})
+	}
+	actualAspects, err := ac.CalcAspects(positions, aspects, cfgPoints, cfgAspects, frameOrbs)
+	if err != nil {
+		return nil, err
+	}
+	framedAspects := make([]domain.FramedAspect, 0, len(actualAspects))
+	for _, actualAspect := range actualAspects {
+		framedAspects = append(framedAspects, domain.FramedAspect{Aspect: actualAspect, Frame: frame})
+	}
+	return framedAspects, nil
+}
+
+// mundanePosition returns the position in house space, based on the proportional position in the diurnal or
+// nocturnal semi-arc (Placidus). Each quadrant covers 90 degrees: the ascendant is at 0, the IC at 90,
+// the descendant at 180 and the MC at 270.
+// Returns an error for circumpolar points, these do not have semi-arcs.
+func mundanePosition(ra, decl, armc, geoLat float64) (float64, error) {
+	tanProduct := math.Tan(mathextra.DegToRad(geoLat)) * math.Tan(mathextra.DegToRad(decl))
+	if math.Abs(tanProduct) >= 1.0 {
+		return 0.0, errors.New("point is circumpolar")
+	}
+	ascDiff := mathextra.RadToDeg(math.Asin(tanProduct))
+	dsa := 90.0 + ascDiff
+	nsa := 90.0 - ascDiff
+	hourAngle := math.Mod(armc-ra+540.0, 360.0) - 180.0 // -180 ..< 180, negative is east
+	var mundPos float64
+	switch {
+	case hourAngle >= -dsa && hourAngle <= dsa: // above horizon
+		mundPos = 270.0 - 90.0*hourAngle/dsa
+	case hourAngle > dsa: // below horizon, west
+		mundPos = 90.0 + 90.0*(180.0-hourAngle)/nsa
+	default: // below horizon, east
+		mundPos = 90.0 - 90.0*(180.0+hourAngle)/nsa
+	}
+	return math.Mod(mundPos+360.0, 360.0), nil
+}
+
+// aspectsForPair returns the actual aspects between two points, using the orbs from the orb table. Aspects with a
+// zero orb are skipped, these cannot occur and would result in an undefined exactness.
+func aspectsForPair(point1, point2 domain.SinglePosition, table *domain.OrbTable) []domain.ActualAspect {
+	const FullCircle = 360.0
+	actualAspects := make([]domain.ActualAspect, 0)
+	orbs := table.Orbs[orbTableIndex(table, point1.Id)][orbTableIndex(table, point2.Id)]
+	distance1 := math.Abs(point1.Position - point2.Position)
+	distance2 := FullCircle - distance1
+	for k, aspect := range table.Aspects {
+		delta := math.Min(math.Abs(distance1-table.Distances[k]), math.Abs(distance2-table.Distances[k]))
+		if orbs[k] > 0.0 && delta <= orbs[k] {
+			actualAspects = append(actualAspects, domain.ActualAspect{
+				Pos1:         point1,
+				Pos2:         point2,
+				ActualAspect: aspect,
+				ActualOrb:    delta,
+				Exactness:    100 - int((delta/orbs[k])*100),
+			})
+		}
+	}
+	return actualAspects
+}
+
+// CalcMovingAspects returns the actual aspects, including the movement and the estimated number of days to exactness.
+// The aspects are the same as for CalcAspects, the speeds are only used to define the movement.
+func (ac AspectsCalculation) CalcMovingAspects(points []domain.PositionWithSpeed,
+	aspects []domain.Aspect,
+	cfgPoints []domain.ConfigPoint,
+	cfgAspects []domain.ConfigAspect,
+	orbs domain.ConfigOrbs) ([]domain.MovingAspect, error) {
+
+	singlePoints := make([]domain.SinglePosition, 0, len(points))
+	speeds := make(map[domain.ChartPoint]float64)
+	for _, point := range points {
+		singlePoints = append(singlePoints, domain.SinglePosition{Id: point.Id, Position: point.Position})
+		speeds[point.Id] = point.Speed
+	}
+	actualAspects, err := ac.CalcAspects(singlePoints, aspects, cfgPoints, cfgAspects, orbs)
+	if err != nil {
+		return nil, err
+	}
+	movingAspects := make([]domain.MovingAspect, 0, len(actualAspects))
+	for _, actualAspect := range actualAspects {
+		speed1 := speeds[actualAspect.Pos1.Id]
+		speed2 := speeds[actualAspect.Pos2.Id]
+		distance := aspectDistance(actualAspect.ActualAspect, cfgAspects)
+		movement, days := aspectMovement(actualAspect.Pos1.Position, actualAspect.Pos2.Position, speed1, speed2, distance)
+		movingAspects = append(movingAspects, domain.MovingAspect{
+			Aspect:      actualAspect,
+			Speed1:      speed1,
+			Speed2:      speed2,
+			Movement:    movement,
+			DaysToExact: days,
+		})
+	}
+	return movingAspects, nil
+}
+
+// aspectMovement defines the movement of an aspect and estimates the days to exactness, assuming constant speeds.
+// The orb is the difference between the shortest arc and the aspect distance, its change per day follows
+// from the relative speed and the direction of the arc.
+func aspectMovement(pos1, pos2, speed1, speed2, aspectDistance float64) (domain.AspectMovement, float64) {
+	relativeSpeed := speed2 - speed1
+	if math.Abs(relativeSpeed) < MinRelativeSpeed {
+		return domain.MovementStationary, 0.0
+	}
+	arc := math.Mod(pos2-pos1, 360.0)
+	if arc > 180.0 {
+		arc -= 360.0
+	}
+	if arc <= -180.0 {
+		arc += 360.0
+	}
+	orb := math.Abs(arc) - aspectDistance
+	if orb == 0.0 {
+		return domain.MovementSeparating, 0.0
+	}
+	orbSpeed := relativeSpeed
+	if arc < 0.0 {
+		orbSpeed = -relativeSpeed
+	}
+	days := -orb / orbSpeed
+	if days > 0.0 {
+		return domain.MovementApplying, days
+	}
+	return domain.MovementSeparating, days
+}
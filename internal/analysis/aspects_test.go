@@ -15,7 +15,7 @@ import (
 
 func TestCalcAspectsHappyFlow(t *testing.T) {
 
-	baseOrb := 10.0
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
 
 	var points = []domain.SinglePosition{
 		{Id: 0, Position: 100.0}, // 60 with 1, 0 with 2, 120 with 6,
@@ -112,7 +112,7 @@ func TestCalcAspectsHappyFlow(t *testing.T) {
 	}
 
 	aspCalc := AspectsCalculation{}
-	result, err := aspCalc.CalcAspects(points, aspects, cfgPoints, cfgAspects, baseOrb)
+	result, err := aspCalc.CalcAspects(points, aspects, cfgPoints, cfgAspects, orbs)
 
 	if err != nil {
 		t.Fatalf("aspects calculation failed, returned unexpected error %v", err)
@@ -157,3 +157,246 @@ func TestCalcAspectsHappyFlow(t *testing.T) {
 		}
 	}
 }
+
+func TestCalcMovingAspects(t *testing.T) {
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0}
+	var points = []domain.PositionWithSpeed{
+		{Id: 0, Position: 100.0, Speed: 1.0},   // Sun
+		{Id: 1, Position: 95.0, Speed: 13.0},   // Moon, applying conjunction with Sun
+		{Id: 4, Position: 283.0, Speed: -0.5},  // Mars, retrograde, applying opposition with Sun and Moon
+		{Id: 6, Position: 218.0, Speed: 0.001}, // Saturn, separating trine with Sun, applying trine with Moon
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: ''},
+		{ActualPoint: 1, OrbFactor: 100, Glyph: ''},
+		{ActualPoint: 4, OrbFactor: 80, Glyph: ''},
+		{ActualPoint: 6, OrbFactor: 60, Glyph: ''},
+	}
+	var aspects = []domain.Aspect{domain.Conjunction, domain.Opposition, domain.Trine}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: domain.Conjunction, OrbFactor: 100, Glyph: ''},
+		{ActualAspect: domain.Opposition, OrbFactor: 100, Glyph: ''},
+		{ActualAspect: domain.Trine, OrbFactor: 80, Glyph: ''},
+	}
+	aspCalc := AspectsCalculation{}
+	result, err := aspCalc.CalcMovingAspects(points, aspects, cfgPoints, cfgAspects, orbs)
+	if err != nil {
+		t.Fatalf("moving aspects calculation failed, returned unexpected error %v", err)
+	}
+	expected := []struct {
+		id1, id2 domain.ChartPoint
+		aspect   domain.Aspect
+		movement domain.AspectMovement
+		days     float64
+	}{
+		{0, 1, domain.Conjunction, domain.MovementApplying, 5.0 / 12.0},
+		{0, 4, domain.Opposition, domain.MovementApplying, 3.0 / 1.5},
+		{0, 6, domain.Trine, domain.MovementSeparating, -2.0 / 0.999},
+		{1, 4, domain.Opposition, domain.MovementApplying, 8.0 / 13.5},
+		{1, 6, domain.Trine, domain.MovementApplying, 3.0 / 12.999},
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Expected %d results, got %d, result was %v", len(expected), len(result), result)
+	}
+	for i, exp := range expected {
+		actual := result[i]
+		if actual.Aspect.Pos1.Id != exp.id1 || actual.Aspect.Pos2.Id != exp.id2 || actual.Aspect.ActualAspect != exp.aspect {
+			t.Errorf("Unexpected aspect at index %d: got %v", i, actual.Aspect)
+		}
+		if actual.Movement != exp.movement {
+			t.Errorf("Wrong movement at index %d: got %d, want %d", i, actual.Movement, exp.movement)
+		}
+		if math.Abs(actual.DaysToExact-exp.days) > 1e-8 {
+			t.Errorf("Wrong days to exactness at index %d: got %f, want %f", i, actual.DaysToExact, exp.days)
+		}
+	}
+}
+
+func TestAspectMovementStationary(t *testing.T) {
+	movement, days := aspectMovement(10.0, 72.0, 0.5, 0.50001, 60.0)
+	if movement != domain.MovementStationary || days != 0.0 {
+		t.Errorf("Expected stationary aspect, got movement %d and days %f", movement, days)
+	}
+}
+
+func TestAspectMovementAcrossZeroAries(t *testing.T) {
+	// arc from 350 to 5 is 15 degrees and grows with 0.5 degrees per day to the aspect distance of 20 degrees
+	movement, days := aspectMovement(350.0, 5.0, 0.5, 1.0, 20.0)
+	if movement != domain.MovementApplying || math.Abs(days-10.0) > 1e-8 {
+		t.Errorf("Expected applying aspect in 10 days, got movement %d and days %f", movement, days)
+	}
+}
+
+func TestCalcInterAspects(t *testing.T) {
+	var pointsA = []domain.SinglePosition{
+		{Id: 0, Position: 100.0}, // Sun A
+		{Id: 1, Position: 102.0}, // Moon A, conjunct Sun A, but that is within the same chart
+	}
+	var pointsB = []domain.SinglePosition{
+		{Id: 0, Position: 281.0}, // Sun B, opposition Sun A and Moon A
+		{Id: 1, Position: 222.0}, // Moon B, trine Moon A and Sun A
+	}
+	var cfgPoints = []domain.ConfigPoint{
+		{ActualPoint: 0, OrbFactor: 100, Glyph: '\uE200'},
+		{ActualPoint: 1, OrbFactor: 100, Glyph: '\uE201'},
+	}
+	var aspects = []domain.Aspect{domain.Conjunction, domain.Opposition, domain.Trine}
+	var cfgAspects = []domain.ConfigAspect{
+		{ActualAspect: domain.Conjunction, OrbFactor: 100, Glyph: '\uE700'},
+		{ActualAspect: domain.Opposition, OrbFactor: 100, Glyph: '\uE710'},
+		{ActualAspect: domain.Trine, OrbFactor: 50, Glyph: '\uE720'},
+	}
+	aspCalc := AspectsCalculation{}
+	result, err := aspCalc.CalcInterAspects(pointsA, pointsB, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	if err != nil {
+		t.Fatalf("inter aspects calculation failed, returned unexpected error %v", err)
+	}
+	if len(result) != 4 {
+		t.Fatalf("Expected 4 results, got %d, result was %v", len(result), result)
+	}
+	expected := []struct {
+		posA, posB float64
+		aspect     domain.Aspect
+		orb        float64
+	}{
+		{100.0, 281.0, domain.Opposition, 1.0},
+		{100.0, 222.0, domain.Trine, 2.0},
+		{102.0, 281.0, domain.Opposition, 1.0},
+		{102.0, 222.0, domain.Trine, 0.0},
+	}
+	for i, exp := range expected {
+		if result[i].Pos1.Position != exp.posA || result[i].Pos2.Position != exp.posB ||
+			result[i].ActualAspect != exp.aspect || math.Abs(result[i].ActualOrb-exp.orb) > 1e-8 {
+			t.Errorf("Unexpected inter aspect at index %d: got %v", i, result[i])
+		}
+	}
+}
+
+func TestCalcFramedAspects(t *testing.T) {
+	points := []domain.PointPosResult{
+		{Point: domain.Sun, LonPos: 10.0, RaPos: 100.0, AzimPos: 200.0},
+		{Point: domain.Moon, LonPos: 40.0, RaPos: 280.5, AzimPos: 290.0},
+	}
+	cfgPoints := []domain.ConfigPoint{
+		{ActualPoint: domain.Sun, OrbFactor: 100},
+		{ActualPoint: domain.Moon, OrbFactor: 100},
+	}
+	aspects := []domain.Aspect{domain.Conjunction, domain.Opposition, domain.Square}
+	cfgAspects := []domain.ConfigAspect{
+		{ActualAspect: domain.Conjunction, OrbFactor: 100},
+		{ActualAspect: domain.Opposition, OrbFactor: 100},
+		{ActualAspect: domain.Square, OrbFactor: 100},
+	}
+	tests := []struct {
+		frame  domain.AspectFrame
+		aspect domain.Aspect
+		orb    float64
+	}{
+		{domain.FrameEquatorial, domain.Opposition, 0.5},
+		{domain.FrameHorizontal, domain.Square, 0.0},
+	}
+	orbs := domain.ConfigOrbs{BaseOrbAspects: 10.0, BaseOrbAspectsEquatorial: 1.0, BaseOrbAspectsHorizontal: 1.0}
+	ac := AspectsCalculation{}
+	for _, tt := range tests {
+		result, err := ac.CalcFramedAspects(points, tt.frame, 0.0, 0.0, aspects, cfgPoints, cfgAspects, orbs)
+		if err != nil {
+			t.Fatalf("CalcFramedAspects returned unexpected error %v", err)
+		}
+		if len(result) != 1 {
+			t.Fatalf("CalcFramedAspects for frame %d expected 1 aspect, got %v", tt.frame, result)
+		}
+		if result[0].Frame != tt.frame || result[0].Aspect.ActualAspect != tt.aspect ||
+			math.Abs(result[0].Aspect.ActualOrb-tt.orb) > delta {
+			t.Errorf("CalcFramedAspects for frame %d expected aspect %d with orb %f, got %v", tt.frame, tt.aspect, tt.orb, result[0])
+		}
+	}
+}
+
+func TestCalcFramedAspectsMundane(t *testing.T) {
+	// at the equator the mundane position is 270 - hour angle: Sun at the MC and Moon at the ascendant
+	points := []domain.PointPosResult{
+		{Point: domain.Sun, RaPos: 100.0, DeclPos: 20.0},
+		{Point: domain.Moon, RaPos: 190.0, DeclPos: -10.0},
+	}
+	cfgPoints := []domain.ConfigPoint{
+		{ActualPoint: domain.Sun, OrbFactor: 100},
+		{ActualPoint: domain.Moon, OrbFactor: 100},
+	}
+	aspects := []domain.Aspect{domain.Square}
+	cfgAspects := []domain.ConfigAspect{{ActualAspect: domain.Square, OrbFactor: 100}}
+	ac := AspectsCalculation{}
+	result, err := ac.CalcFramedAspects(points, domain.FrameMundane, 100.0, 0.0, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspectsMundane: 1.0})
+	if err != nil {
+		t.Fatalf("CalcFramedAspects returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Frame != domain.FrameMundane || math.Abs(result[0].Aspect.ActualOrb) > delta {
+		t.Errorf("CalcFramedAspects expected exact mundane square, got %v", result)
+	}
+}
+
+func TestCalcFramedAspectsCircumpolar(t *testing.T) {
+	points := []domain.PointPosResult{
+		{Point: domain.Sun, RaPos: 100.0, DeclPos: 30.0},
+		{Point: domain.Moon, RaPos: 190.0, DeclPos: 0.0},
+	}
+	cfgPoints := []domain.ConfigPoint{
+		{ActualPoint: domain.Sun, OrbFactor: 100},
+		{ActualPoint: domain.Moon, OrbFactor: 100},
+	}
+	aspects := []domain.Aspect{domain.Square}
+	cfgAspects := []domain.ConfigAspect{{ActualAspect: domain.Square, OrbFactor: 100}}
+	ac := AspectsCalculation{}
+	_, err := ac.CalcFramedAspects(points, domain.FrameMundane, 100.0, 70.0, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspectsMundane: 1.0})
+	if err == nil {
+		t.Errorf("CalcFramedAspects expected error for circumpolar point")
+	}
+}
+
+func TestMundanePosition(t *testing.T) {
+	tests := []struct {
+		ra, decl, armc, geoLat float64
+		expected               float64
+	}{
+		{100.0, 0.0, 100.0, 0.0, 270.0},       // MC
+		{190.0, 0.0, 100.0, 0.0, 0.0},         // ascendant
+		{10.0, 0.0, 100.0, 0.0, 180.0},        // descendant
+		{280.0, 0.0, 100.0, 0.0, 90.0},        // IC
+		{158.88297, 20.0, 100.0, 52.0, 315.0}, // halfway the diurnal semi-arc, east
+		{30.0, 20.0, 100.0, 52.0, 216.50406},  // west, above horizon
+	}
+	for _, tt := range tests {
+		result, err := mundanePosition(tt.ra, tt.decl, tt.armc, tt.geoLat)
+		if err != nil {
+			t.Fatalf("mundanePosition returned unexpected error %v", err)
+		}
+		if math.Abs(result-tt.expected) > 0.001 {
+			t.Errorf("mundanePosition(%f, %f, %f, %f) expected %f, got %f", tt.ra, tt.decl, tt.armc, tt.geoLat, tt.expected, result)
+		}
+	}
+}
+
+func TestCalcAspectsCustomAspect(t *testing.T) {
+	tredecile := domain.FirstCustomAspect
+	points := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 37.9},
+	}
+	cfgPoints := []domain.ConfigPoint{
+		{ActualPoint: domain.Sun, OrbFactor: 100},
+		{ActualPoint: domain.Moon, OrbFactor: 100},
+	}
+	aspects := []domain.Aspect{domain.Conjunction, tredecile}
+	cfgAspects := []domain.ConfigAspect{
+		{ActualAspect: domain.Conjunction, OrbFactor: 100},
+		{ActualAspect: tredecile, OrbFactor: 10, Name: "Tredecile", Distance: 360.0 / 13.0},
+	}
+	ac := AspectsCalculation{}
+	result, err := ac.CalcAspects(points, aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	if err != nil {
+		t.Fatalf("CalcAspects returned unexpected error %v", err)
+	}
+	expectedOrb := 27.9 - 360.0/13.0
+	if len(result) != 1 || result[0].ActualAspect != tredecile || math.Abs(result[0].ActualOrb-expectedOrb) > delta {
+		t.Errorf("CalcAspects expected tredecile with orb %f, got %v", expectedOrb, result)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/se"
+	"math"
+	"sort"
+)
+
+// DeclStripCalculator calculates the data for a declination strip or a declination diagram.
+type DeclStripCalculator interface {
+	CalcDeclStrip(positions []domain.DoublePosition, jdUt float64, orb float64) (domain.DeclinationStrip, error)
+}
+
+type DeclStripCalculation struct {
+	epsCalc se.SwephEpsilonCalculator
+	leCalc  LongEquivCalculator
+}
+
+func NewDeclStripCalculation() DeclStripCalculator {
+	ec := se.NewSwephEpsilonCalculation()
+	lec := NewLongEquivCalculation()
+	return DeclStripCalculation{ec, lec}
+}
+
+// CalcDeclStrip calculates the declination strip for positions with longitude (Position1) and declination
+// (Position2). The bounds are defined by the true obliquity for jdUt. Clusters are found by scanning the points,
+// sorted by absolute declination: a point joins the current cluster if it is within orb from the first point of
+// that cluster.
+func (dsc DeclStripCalculation) CalcDeclStrip(positions []domain.DoublePosition, jdUt float64, orb float64) (domain.DeclinationStrip, error) {
+	obliquity, err := dsc.epsCalc.CalcEpsilon(jdUt, true)
+	if err != nil {
+		return domain.DeclinationStrip{}, err
+	}
+	equivalents, err := dsc.leCalc.CalcEquivalents(positions, obliquity)
+	if err != nil {
+		return domain.DeclinationStrip{}, err
+	}
+	items := make([]domain.DeclinationItem, 0, len(positions))
+	for i, pos := range positions {
+		items = append(items, domain.DeclinationItem{
+			Point:          pos.Id,
+			Longitude:      pos.Position1,
+			Declination:    pos.Position2,
+			OutOfBounds:    math.Abs(pos.Position2) > obliquity,
+			LongEquivalent: equivalents[i].Position,
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Declination > items[j].Declination
+	})
+	return domain.DeclinationStrip{
+		Items:     items,
+		Obliquity: obliquity,
+		Clusters:  declinationClusters(items, orb),
+	}, nil
+}
+
+// declinationClusters returns the clusters with at least two points.
+func declinationClusters(items []domain.DeclinationItem, orb float64) []domain.DeclinationCluster {
+	sorted := make([]domain.DeclinationItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return math.Abs(sorted[i].Declination) < math.Abs(sorted[j].Declination)
+	})
+	clusters := make([]domain.DeclinationCluster, 0)
+	start := 0
+	for start < len(sorted) {
+		end := start + 1
+		for end < len(sorted) && math.Abs(sorted[end].Declination)-math.Abs(sorted[start].Declination) <= orb {
+			end++
+		}
+		if end-start >= 2 {
+			clusters = append(clusters, createCluster(sorted[start:end]))
+		}
+		start = end
+	}
+	return clusters
+}
+
+func createCluster(members []domain.DeclinationItem) domain.DeclinationCluster {
+	points := make([]domain.ChartPoint, 0, len(members))
+	north, south := false, false
+	for _, member := range members {
+		points = append(points, member.Point)
+		north = north || member.Declination >= 0.0
+		south = south || member.Declination < 0.0
+	}
+	return domain.DeclinationCluster{
+		Points:     points,
+		MinAbsDecl: math.Abs(members[0].Declination),
+		MaxAbsDecl: math.Abs(members[len(members)-1].Declination),
+		HasContra:  north && south,
+	}
+}
@@ -0,0 +1,67 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+func TestCalcDeclStrip(t *testing.T) {
+	positions := []domain.DoublePosition{
+		{Id: domain.Sun, Position1: 90.0, Position2: 23.4},
+		{Id: domain.Moon, Position1: 100.0, Position2: -23.0},
+		{Id: domain.Mars, Position1: 10.0, Position2: 3.9},
+		{Id: domain.Venus, Position1: 200.0, Position2: 25.0},
+		{Id: domain.Jupiter, Position1: 1.0, Position2: 0.0},
+	}
+	dsc := DeclStripCalculation{FakeSeEpsilonCalculation{}, LongEquivCalculation{}} // obliquity 23.5
+	result, err := dsc.CalcDeclStrip(positions, 2_451_545.0, 1.0)
+	if err != nil {
+		t.Fatalf("CalcDeclStrip returned unexpected error %v", err)
+	}
+	if math.Abs(result.Obliquity-23.5) > delta {
+		t.Errorf("CalcDeclStrip expected obliquity 23.5, got %f", result.Obliquity)
+	}
+	expectedOrder := []domain.ChartPoint{domain.Venus, domain.Sun, domain.Mars, domain.Jupiter, domain.Moon}
+	for i, point := range expectedOrder {
+		if result.Items[i].Point != point {
+			t.Errorf("CalcDeclStrip expected point %d at index %d, got %d", point, i, result.Items[i].Point)
+		}
+	}
+	if !result.Items[0].OutOfBounds || result.Items[1].OutOfBounds {
+		t.Errorf("CalcDeclStrip expected only Venus out of bounds, got %v", result.Items[:2])
+	}
+	if math.Abs(result.Items[3].LongEquivalent) > delta {
+		t.Errorf("CalcDeclStrip expected longitude equivalent 0.0 for Jupiter, got %f", result.Items[3].LongEquivalent)
+	}
+	if len(result.Clusters) != 1 {
+		t.Fatalf("CalcDeclStrip expected 1 cluster, got %v", result.Clusters)
+	}
+	cluster := result.Clusters[0]
+	if len(cluster.Points) != 2 || cluster.Points[0] != domain.Moon || cluster.Points[1] != domain.Sun || !cluster.HasContra {
+		t.Errorf("CalcDeclStrip expected contra cluster Moon - Sun, got %v", cluster)
+	}
+	if math.Abs(cluster.MinAbsDecl-23.0) > delta || math.Abs(cluster.MaxAbsDecl-23.4) > delta {
+		t.Errorf("CalcDeclStrip expected cluster from 23.0 to 23.4, got %v", cluster)
+	}
+}
+
+func TestDeclinationClustersChain(t *testing.T) {
+	// 10.0, 10.8 and 11.6: the third point is not within the orb from the first point and starts a new cluster
+	items := []domain.DeclinationItem{
+		{Point: domain.Sun, Declination: 10.0},
+		{Point: domain.Moon, Declination: 10.8},
+		{Point: domain.Mars, Declination: 11.6},
+	}
+	clusters := declinationClusters(items, 1.0)
+	if len(clusters) != 1 || len(clusters[0].Points) != 2 || clusters[0].HasContra {
+		t.Errorf("declinationClusters expected one cluster with Sun and Moon, got %v", clusters)
+	}
+}
@@ -0,0 +1,303 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"fmt"
+	"math"
+)
+
+// DignitiesCalculator calculates the essential dignities of the classical planets and the almuten of a degree.
+type DignitiesCalculator interface {
+	CalcDignities(points []domain.SinglePosition,
+		diurnal bool,
+		triplicity domain.TriplicitySystem,
+		terms domain.TermsSystem) ([]domain.DignityScore, error)
+	CalcAlmuten(position float64,
+		diurnal bool,
+		triplicity domain.TriplicitySystem,
+		terms domain.TermsSystem) (domain.Almuten, error)
+	CalcAlmutenFiguris(positions []float64,
+		diurnal bool,
+		triplicity domain.TriplicitySystem,
+		terms domain.TermsSystem) (domain.Almuten, error)
+}
+
+type DignitiesCalculation struct{}
+
+func NewDignitiesCalculation() DignitiesCalculator {
+	return DignitiesCalculation{}
+}
+
+// classicalPlanets in the Chaldean order, this order is also used for the faces.
+var classicalPlanets = []domain.ChartPoint{
+	domain.Saturn, domain.Jupiter, domain.Mars, domain.Sun, domain.Venus, domain.Mercury, domain.Moon,
+}
+
+var domicileRulers = [12]domain.ChartPoint{
+	domain.Mars, domain.Venus, domain.Mercury, domain.Moon, domain.Sun, domain.Mercury,
+	domain.Venus, domain.Mars, domain.Jupiter, domain.Saturn, domain.Saturn, domain.Jupiter,
+}
+
+var exaltationRulers = map[domain.Sign]domain.ChartPoint{
+	domain.Aries:     domain.Sun,
+	domain.Taurus:    domain.Moon,
+	domain.Cancer:    domain.Jupiter,
+	domain.Virgo:     domain.Mercury,
+	domain.Libra:     domain.Saturn,
+	domain.Capricorn: domain.Mars,
+	domain.Pisces:    domain.Venus,
+}
+
+// triplicity rulers per element (fire, earth, air, water): day ruler, night ruler and participating ruler.
+var dorotheanTriplicities = [4][3]domain.ChartPoint{
+	{domain.Sun, domain.Jupiter, domain.Saturn},
+	{domain.Venus, domain.Moon, domain.Mars},
+	{domain.Saturn, domain.Mercury, domain.Jupiter},
+	{domain.Venus, domain.Mars, domain.Moon},
+}
+
+// triplicity rulers per element (fire, earth, air, water): day ruler and night ruler.
+var lillyTriplicities = [4][2]domain.ChartPoint{
+	{domain.Sun, domain.Jupiter},
+	{domain.Venus, domain.Moon},
+	{domain.Saturn, domain.Mercury},
+	{domain.Mars, domain.Mars},
+}
+
+var ptolemaicTriplicities = [4][2]domain.ChartPoint{
+	{domain.Sun, domain.Jupiter},
+	{domain.Venus, domain.Moon},
+	{domain.Saturn, domain.Mercury},
+	{domain.Venus, domain.Mars},
+}
+
+// termBound is the end of a term within a sign, in degrees, and the ruler of that term.
+type termBound struct {
+	end   float64
+	ruler domain.ChartPoint
+}
+
+var egyptianTerms = [12][5]termBound{
+	{{6, domain.Jupiter}, {12, domain.Venus}, {20, domain.Mercury}, {25, domain.Mars}, {30, domain.Saturn}},
+	{{8, domain.Venus}, {14, domain.Mercury}, {22, domain.Jupiter}, {27, domain.Saturn}, {30, domain.Mars}},
+	{{6, domain.Mercury}, {12, domain.Jupiter}, {17, domain.Venus}, {24, domain.Mars}, {30, domain.Saturn}},
+	{{7, domain.Mars}, {13, domain.Venus}, {19, domain.Mercury}, {26, domain.Jupiter}, {30, domain.Saturn}},
+	{{6, domain.Jupiter}, {11, domain.Venus}, {18, domain.Saturn}, {24, domain.Mercury}, {30, domain.Mars}},
+	{{7, domain.Mercury}, {17, domain.Venus}, {21, domain.Jupiter}, {28, domain.Mars}, {30, domain.Saturn}},
+	{{6, domain.Saturn}, {14, domain.Mercury}, {21, domain.Jupiter}, {28, domain.Venus}, {30, domain.Mars}},
+	{{7, domain.Mars}, {11, domain.Venus}, {19, domain.Mercury}, {24, domain.Jupiter}, {30, domain.Saturn}},
+	{{12, domain.Jupiter}, {17, domain.Venus}, {21, domain.Mercury}, {26, domain.Saturn}, {30, domain.Mars}},
+	{{7, domain.Mercury}, {14, domain.Jupiter}, {22, domain.Venus}, {26, domain.Saturn}, {30, domain.Mars}},
+	{{7, domain.Mercury}, {13, domain.Venus}, {20, domain.Jupiter}, {25, domain.Mars}, {30, domain.Saturn}},
+	{{12, domain.Venus}, {16, domain.Jupiter}, {19, domain.Mercury}, {28, domain.Mars}, {30, domain.Saturn}},
+}
+
+var ptolemaicTerms = [12][5]termBound{
+	{{6, domain.Jupiter}, {14, domain.Venus}, {21, domain.Mercury}, {26, domain.Mars}, {30, domain.Saturn}},
+	{{8, domain.Venus}, {15, domain.Mercury}, {22, domain.Jupiter}, {26, domain.Saturn}, {30, domain.Mars}},
+	{{7, domain.Mercury}, {14, domain.Jupiter}, {21, domain.Venus}, {25, domain.Saturn}, {30, domain.Mars}},
+	{{6, domain.Mars}, {13, domain.Jupiter}, {20, domain.Mercury}, {27, domain.Venus}, {30, domain.Saturn}},
+	{{6, domain.Saturn}, {13, domain.Mercury}, {19, domain.Venus}, {25, domain.Jupiter}, {30, domain.Mars}},
+	{{7, domain.Mercury}, {13, domain.Venus}, {18, domain.Jupiter}, {24, domain.Saturn}, {30, domain.Mars}},
+	{{6, domain.Saturn}, {11, domain.Venus}, {19, domain.Jupiter}, {24, domain.Mercury}, {30, domain.Mars}},
+	{{6, domain.Mars}, {14, domain.Jupiter}, {21, domain.Venus}, {27, domain.Mercury}, {30, domain.Saturn}},
+	{{8, domain.Jupiter}, {14, domain.Venus}, {19, domain.Mercury}, {25, domain.Saturn}, {30, domain.Mars}},
+	{{6, domain.Venus}, {12, domain.Mercury}, {19, domain.Jupiter}, {25, domain.Mars}, {30, domain.Saturn}},
+	{{6, domain.Saturn}, {12, domain.Mercury}, {20, domain.Venus}, {25, domain.Jupiter}, {30, domain.Mars}},
+	{{8, domain.Venus}, {14, domain.Jupiter}, {20, domain.Mercury}, {26, domain.Mars}, {30, domain.Saturn}},
+}
+
+// CalcDignities returns the dignities, debilities and score for each planet.
+// PRE all points are classical planets (Sun .. Saturn)
+// PRE for all positions: 0.0 <= position < 360.0
+// POST no errors -> returns the dignity scores in the same sequence as points
+// POST errors: returns nil and error
+func (dc DignitiesCalculation) CalcDignities(points []domain.SinglePosition,
+	diurnal bool,
+	triplicity domain.TriplicitySystem,
+	terms domain.TermsSystem) ([]domain.DignityScore, error) {
+
+	scores := make([]domain.DignityScore, 0, len(points))
+	for _, point := range points {
+		rulers, err := degreeRulers(point.Position, diurnal, triplicity, terms)
+		if err != nil {
+			return nil, err
+		}
+		sign := signOfPosition(point.Position)
+		dignities := make([]domain.Dignity, 0)
+		for _, dignity := range []domain.Dignity{domain.DignityDomicile, domain.DignityExaltation,
+			domain.DignityTriplicity, domain.DignityTerms, domain.DignityFace} {
+			for _, ruler := range rulers[dignity] {
+				if ruler == point.Id {
+					dignities = append(dignities, dignity)
+				}
+			}
+		}
+		peregrine := len(dignities) == 0
+		if domicileRulers[opposingSign(sign)] == point.Id {
+			dignities = append(dignities, domain.DignityDetriment)
+		}
+		if ruler, found := exaltationRulers[opposingSign(sign)]; found && ruler == point.Id {
+			dignities = append(dignities, domain.DignityFall)
+		}
+		scores = append(scores, domain.DignityScore{
+			Pos:       point,
+			Sign:      sign,
+			Dignities: dignities,
+			Score:     dignityScore(dignities),
+			Peregrine: peregrine,
+		})
+	}
+	return scores, nil
+}
+
+// CalcAlmuten returns the almuten of a degree: the planet with the highest score for the rulerships of that degree.
+// Each rulership counts with the score of the corresponding dignity.
+// PRE 0.0 <= position < 360.0
+// POST no errors -> returns the almuten
+// POST errors: returns empty almuten and error
+func (dc DignitiesCalculation) CalcAlmuten(position float64,
+	diurnal bool,
+	triplicity domain.TriplicitySystem,
+	terms domain.TermsSystem) (domain.Almuten, error) {
+	return dc.CalcAlmutenFiguris([]float64{position}, diurnal, triplicity, terms)
+}
+
+// CalcAlmutenFiguris returns the almuten for a set of positions, the scores for all positions are added.
+// For the almuten figuris the positions are normally the Sun, the Moon, the ascendant, the Part of Fortune and the
+// prenatal syzygy.
+// PRE length positions >= 1
+// PRE for all positions: 0.0 <= position < 360.0
+// POST no errors -> returns the almuten
+// POST errors: returns empty almuten and error
+func (dc DignitiesCalculation) CalcAlmutenFiguris(positions []float64,
+	diurnal bool,
+	triplicity domain.TriplicitySystem,
+	terms domain.TermsSystem) (domain.Almuten, error) {
+
+	totals := make(map[domain.ChartPoint]int)
+	for _, position := range positions {
+		rulers, err := degreeRulers(position, diurnal, triplicity, terms)
+		if err != nil {
+			return domain.Almuten{}, err
+		}
+		for _, dignityData := range domain.AllDignities() {
+			for _, ruler := range rulers[dignityData.Key] {
+				totals[ruler] += dignityData.Score
+			}
+		}
+	}
+	almuten := domain.Almuten{Rulers: make([]domain.ChartPoint, 0), Scores: make([]domain.PlanetScore, 0)}
+	almuten.Score = math.MinInt
+	for _, planet := range classicalPlanets {
+		score := totals[planet]
+		almuten.Scores = append(almuten.Scores, domain.PlanetScore{Point: planet, Score: score})
+		switch {
+		case score > almuten.Score:
+			almuten.Score = score
+			almuten.Rulers = []domain.ChartPoint{planet}
+		case score == almuten.Score:
+			almuten.Rulers = append(almuten.Rulers, planet)
+		}
+	}
+	return almuten, nil
+}
+
+// degreeRulers returns the rulers of a degree for the dignities domicile, exaltation, triplicity, terms and face.
+func degreeRulers(position float64,
+	diurnal bool,
+	triplicity domain.TriplicitySystem,
+	terms domain.TermsSystem) (map[domain.Dignity][]domain.ChartPoint, error) {
+
+	sign := signOfPosition(position)
+	rulers := make(map[domain.Dignity][]domain.ChartPoint)
+	rulers[domain.DignityDomicile] = []domain.ChartPoint{domicileRulers[sign]}
+	if ruler, found := exaltationRulers[sign]; found {
+		rulers[domain.DignityExaltation] = []domain.ChartPoint{ruler}
+	}
+	tripRulers, err := triplicityRulers(sign, diurnal, triplicity)
+	if err != nil {
+		return nil, err
+	}
+	rulers[domain.DignityTriplicity] = tripRulers
+	termRuler, err := termRuler(position, terms)
+	if err != nil {
+		return nil, err
+	}
+	rulers[domain.DignityTerms] = []domain.ChartPoint{termRuler}
+	rulers[domain.DignityFace] = []domain.ChartPoint{faceRuler(position)}
+	return rulers, nil
+}
+
+// triplicityRulers returns the ruler by day or by night and, for the Dorothean system, the participating ruler.
+func triplicityRulers(sign domain.Sign, diurnal bool, triplicity domain.TriplicitySystem) ([]domain.ChartPoint, error) {
+	element := int(sign) % 4
+	sectIndex := 0
+	if !diurnal {
+		sectIndex = 1
+	}
+	switch triplicity {
+	case domain.TriplicityDorothean:
+		return []domain.ChartPoint{dorotheanTriplicities[element][sectIndex], dorotheanTriplicities[element][2]}, nil
+	case domain.TriplicityLilly:
+		return []domain.ChartPoint{lillyTriplicities[element][sectIndex]}, nil
+	case domain.TriplicityPtolemaic:
+		return []domain.ChartPoint{ptolemaicTriplicities[element][sectIndex]}, nil
+	default:
+		return nil, fmt.Errorf("unknown triplicity system %d", triplicity)
+	}
+}
+
+func termRuler(position float64, terms domain.TermsSystem) (domain.ChartPoint, error) {
+	var table [12][5]termBound
+	switch terms {
+	case domain.TermsEgyptian:
+		table = egyptianTerms
+	case domain.TermsPtolemaic:
+		table = ptolemaicTerms
+	default:
+		return 0, fmt.Errorf("unknown terms system %d", terms)
+	}
+	sign := signOfPosition(position)
+	degreeInSign := position - float64(sign)*30.0
+	for _, bound := range table[sign] {
+		if degreeInSign < bound.end {
+			return bound.ruler, nil
+		}
+	}
+	return table[sign][4].ruler, nil
+}
+
+// faceRuler returns the ruler of the face (decan), the faces follow the Chaldean order starting with Mars at 0 Aries.
+func faceRuler(position float64) domain.ChartPoint {
+	const marsIndex = 2
+	face := int(position / 10.0)
+	return classicalPlanets[(face+marsIndex)%len(classicalPlanets)]
+}
+
+func signOfPosition(position float64) domain.Sign {
+	return domain.Sign(int(position/30.0) % 12)
+}
+
+func opposingSign(sign domain.Sign) domain.Sign {
+	return (sign + 6) % 12
+}
+
+func dignityScore(dignities []domain.Dignity) int {
+	score := 0
+	for _, dignity := range dignities {
+		for _, dignityData := range domain.AllDignities() {
+			if dignityData.Key == dignity {
+				score += dignityData.Score
+			}
+		}
+	}
+	return score
+}
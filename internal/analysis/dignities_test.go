@@ -0,0 +1,134 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func TestCalcDignitiesDomicile(t *testing.T) {
+	points := []domain.SinglePosition{{Id: domain.Sun, Position: 135.0}} // 15 Leo
+	dc := DignitiesCalculation{}
+	result, err := dc.CalcDignities(points, true, domain.TriplicityLilly, domain.TermsEgyptian)
+	if err != nil {
+		t.Fatalf("CalcDignities returned unexpected error %v", err)
+	}
+	expected := []domain.Dignity{domain.DignityDomicile, domain.DignityTriplicity}
+	if len(result[0].Dignities) != len(expected) {
+		t.Fatalf("CalcDignities expected %v, got %v", expected, result[0].Dignities)
+	}
+	for i, dignity := range expected {
+		if result[0].Dignities[i] != dignity {
+			t.Errorf("CalcDignities expected %v at index %d, got %v", dignity, i, result[0].Dignities[i])
+		}
+	}
+	if result[0].Score != 8 || result[0].Peregrine || result[0].Sign != domain.Leo {
+		t.Errorf("CalcDignities expected score 8 in Leo, got %v", result[0])
+	}
+}
+
+func TestCalcDignitiesFallAndTriplicitySystem(t *testing.T) {
+	points := []domain.SinglePosition{{Id: domain.Saturn, Position: 19.0}} // 19 Aries
+	dc := DignitiesCalculation{}
+	lilly, err := dc.CalcDignities(points, true, domain.TriplicityLilly, domain.TermsEgyptian)
+	if err != nil {
+		t.Fatalf("CalcDignities returned unexpected error %v", err)
+	}
+	if lilly[0].Score != -4 || !lilly[0].Peregrine {
+		t.Errorf("CalcDignities expected peregrine with score -4 for Lilly, got %v", lilly[0])
+	}
+	dorothean, err := dc.CalcDignities(points, true, domain.TriplicityDorothean, domain.TermsEgyptian)
+	if err != nil {
+		t.Fatalf("CalcDignities returned unexpected error %v", err)
+	}
+	if dorothean[0].Score != -1 || dorothean[0].Peregrine {
+		t.Errorf("CalcDignities expected participating triplicity with score -1 for Dorothean, got %v", dorothean[0])
+	}
+}
+
+func TestCalcDignitiesDetriment(t *testing.T) {
+	points := []domain.SinglePosition{{Id: domain.Venus, Position: 185.0}} // 5 Libra
+	dc := DignitiesCalculation{}
+	result, err := dc.CalcDignities([]domain.SinglePosition{{Id: domain.Mars, Position: 185.0}}, false,
+		domain.TriplicityLilly, domain.TermsEgyptian)
+	if err != nil {
+		t.Fatalf("CalcDignities returned unexpected error %v", err)
+	}
+	if result[0].Score != -5 || !result[0].Peregrine {
+		t.Errorf("CalcDignities expected detriment for Mars in Libra, got %v", result[0])
+	}
+	result, err = dc.CalcDignities(points, false, domain.TriplicityLilly, domain.TermsEgyptian)
+	if err != nil {
+		t.Fatalf("CalcDignities returned unexpected error %v", err)
+	}
+	if result[0].Score != 5 {
+		t.Errorf("CalcDignities expected domicile for Venus in Libra, got %v", result[0])
+	}
+}
+
+func TestCalcDignitiesUnknownTerms(t *testing.T) {
+	points := []domain.SinglePosition{{Id: domain.Sun, Position: 135.0}}
+	dc := DignitiesCalculation{}
+	if _, err := dc.CalcDignities(points, true, domain.TriplicityLilly, domain.TermsSystem(99)); err == nil {
+		t.Errorf("CalcDignities expected error for unknown terms system")
+	}
+}
+
+func TestCalcAlmuten(t *testing.T) {
+	dc := DignitiesCalculation{}
+	result, err := dc.CalcAlmuten(15.0, true, domain.TriplicityLilly, domain.TermsEgyptian) // 15 Aries
+	if err != nil {
+		t.Fatalf("CalcAlmuten returned unexpected error %v", err)
+	}
+	if len(result.Rulers) != 1 || result.Rulers[0] != domain.Sun || result.Score != 8 {
+		t.Errorf("CalcAlmuten expected Sun with score 8, got %v", result)
+	}
+	if len(result.Scores) != 7 {
+		t.Errorf("CalcAlmuten expected scores for 7 planets, got %d", len(result.Scores))
+	}
+}
+
+func TestCalcAlmutenFiguris(t *testing.T) {
+	positions := []float64{15.0, 125.0} // 15 Aries and 5 Leo, by day
+	dc := DignitiesCalculation{}
+	result, err := dc.CalcAlmutenFiguris(positions, true, domain.TriplicityLilly, domain.TermsEgyptian)
+	if err != nil {
+		t.Fatalf("CalcAlmutenFiguris returned unexpected error %v", err)
+	}
+	// Aries: Mars 5, Sun 4 + 3 + 1, Mercury 2. Leo: Sun 5 + 3, Jupiter 2, Saturn 1.
+	if len(result.Rulers) != 1 || result.Rulers[0] != domain.Sun || result.Score != 16 {
+		t.Errorf("CalcAlmutenFiguris expected Sun with score 16, got %v", result)
+	}
+}
+
+func TestCalcAlmutenFigurisSharedScore(t *testing.T) {
+	positions := []float64{15.0, 195.0} // 15 Aries and 15 Libra, by night
+	dc := DignitiesCalculation{}
+	result, err := dc.CalcAlmutenFiguris(positions, false, domain.TriplicityLilly, domain.TermsEgyptian)
+	if err != nil {
+		t.Fatalf("CalcAlmutenFiguris returned unexpected error %v", err)
+	}
+	// all planets except the Moon score 5
+	if len(result.Rulers) != 6 || result.Score != 5 {
+		t.Errorf("CalcAlmutenFiguris expected 6 rulers with score 5, got %v", result)
+	}
+}
+
+func TestFaceRuler(t *testing.T) {
+	expected := map[float64]domain.ChartPoint{
+		0.0:   domain.Mars,
+		25.0:  domain.Venus,
+		355.0: domain.Mars,
+	}
+	for position, ruler := range expected {
+		if result := faceRuler(position); result != ruler {
+			t.Errorf("faceRuler expected %v for %f, got %v", ruler, position, result)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"fmt"
+)
+
+// DispositorsCalculator calculates the dispositor graph and the mutual receptions of a chart.
+type DispositorsCalculator interface {
+	CalcDispositors(chart domain.FullChartResponse, system domain.RulershipSystem) (domain.DispositorGraph, error)
+}
+
+type DispositorsCalculation struct{}
+
+func NewDispositorsCalculation() DispositorsCalculator {
+	return DispositorsCalculation{}
+}
+
+var modernDomicileRulers = [12]domain.ChartPoint{
+	domain.Mars, domain.Venus, domain.Mercury, domain.Moon, domain.Sun, domain.Mercury,
+	domain.Venus, domain.Pluto, domain.Jupiter, domain.Saturn, domain.Uranus, domain.Neptune,
+}
+
+// CalcDispositors calculates the dispositor graph for the planets in the chart. For the traditional system the
+// planets Sun .. Saturn are used, for the modern system also Uranus, Neptune and Pluto. Other points are ignored.
+// PRE the chart contains all planets that are used for the rulership system
+// PRE for all planets: 0.0 <= LonPos < 360.0
+// POST no errors -> returns the dispositor graph
+// POST errors: returns empty graph and error
+func (dc DispositorsCalculation) CalcDispositors(chart domain.FullChartResponse, system domain.RulershipSystem) (domain.DispositorGraph, error) {
+	var rulers [12]domain.ChartPoint
+	lastPlanet := domain.Saturn
+	switch system {
+	case domain.RulershipTraditional:
+		rulers = domicileRulers
+	case domain.RulershipModern:
+		rulers = modernDomicileRulers
+		lastPlanet = domain.Pluto
+	default:
+		return domain.DispositorGraph{}, fmt.Errorf("unknown rulership system %d", system)
+	}
+	nodes := make([]domain.DispositorNode, 0)
+	index := make(map[domain.ChartPoint]int)
+	for _, point := range chart.Points {
+		if point.Point < domain.Sun || point.Point > lastPlanet {
+			continue
+		}
+		sign := signOfPosition(point.LonPos)
+		index[point.Point] = len(nodes)
+		nodes = append(nodes, domain.DispositorNode{
+			Point:      point.Point,
+			Sign:       sign,
+			Dispositor: rulers[sign],
+			Disposes:   make([]domain.ChartPoint, 0),
+		})
+	}
+	for planet := domain.Sun; planet <= lastPlanet; planet++ {
+		if _, found := index[planet]; !found {
+			return domain.DispositorGraph{}, fmt.Errorf("planet %d is missing in the chart", planet)
+		}
+	}
+	for _, node := range nodes {
+		if node.Dispositor != node.Point {
+			dispositor := &nodes[index[node.Dispositor]]
+			dispositor.Disposes = append(dispositor.Disposes, node.Point)
+		}
+	}
+	finalDispositors, loops := dispositorCycles(nodes, index)
+	return domain.DispositorGraph{
+		Nodes:            nodes,
+		FinalDispositors: finalDispositors,
+		Loops:            loops,
+		MutualReceptions: mutualReceptions(nodes, rulers),
+	}, nil
+}
+
+// dispositorCycles finds the cycles in the graph and defines the depth for all nodes. As each planet has exactly one
+// dispositor, every chain ends in a cycle. A cycle with one planet is a final dispositor, longer cycles are loops.
+func dispositorCycles(nodes []domain.DispositorNode, index map[domain.ChartPoint]int) ([]domain.ChartPoint, [][]domain.ChartPoint) {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	finalDispositors := make([]domain.ChartPoint, 0)
+	loops := make([][]domain.ChartPoint, 0)
+	state := make([]int, len(nodes))
+	for start := range nodes {
+		path := make([]int, 0)
+		current := start
+		for state[current] == unvisited {
+			state[current] = onPath
+			path = append(path, current)
+			current = index[nodes[current].Dispositor]
+		}
+		tail := len(path)
+		if state[current] == onPath { // new cycle, starts at current
+			cycleStart := 0
+			for path[cycleStart] != current {
+				cycleStart++
+			}
+			tail = cycleStart
+			cycle := make([]domain.ChartPoint, 0, len(path)-cycleStart)
+			for _, i := range path[cycleStart:] {
+				cycle = append(cycle, nodes[i].Point)
+				nodes[i].Depth = 0
+			}
+			if len(cycle) == 1 {
+				finalDispositors = append(finalDispositors, cycle[0])
+			} else {
+				loops = append(loops, rotateToLowest(cycle))
+			}
+		}
+		for i := tail - 1; i >= 0; i-- { // nodes leading to a cycle, in reverse order
+			nodes[path[i]].Depth = nodes[index[nodes[path[i]].Dispositor]].Depth + 1
+		}
+		for _, i := range path {
+			state[i] = done
+		}
+	}
+	return finalDispositors, loops
+}
+
+// rotateToLowest rotates a cycle so that it starts with the point with the lowest id, the sequence is not changed.
+func rotateToLowest(cycle []domain.ChartPoint) []domain.ChartPoint {
+	lowest := 0
+	for i, point := range cycle {
+		if point < cycle[lowest] {
+			lowest = i
+		}
+	}
+	return append(cycle[lowest:], cycle[:lowest]...)
+}
+
+// mutualReceptions finds the pairs of planets that occupy each others domicile or exaltation.
+func mutualReceptions(nodes []domain.DispositorNode, rulers [12]domain.ChartPoint) []domain.MutualReception {
+	receptions := make([]domain.MutualReception, 0)
+	for i := 0; i < len(nodes); i++ {
+		for j := i + 1; j < len(nodes); j++ {
+			node1, node2 := nodes[i], nodes[j]
+			if rulers[node1.Sign] == node2.Point && rulers[node2.Sign] == node1.Point {
+				receptions = append(receptions, domain.MutualReception{
+					Point1: node1.Point, Point2: node2.Point, Reception: domain.ReceptionDomicile})
+			}
+			exalt1, found1 := exaltationRulers[node1.Sign]
+			exalt2, found2 := exaltationRulers[node2.Sign]
+			if found1 && found2 && exalt1 == node2.Point && exalt2 == node1.Point {
+				receptions = append(receptions, domain.MutualReception{
+					Point1: node1.Point, Point2: node2.Point, Reception: domain.ReceptionExaltation})
+			}
+		}
+	}
+	return receptions
+}
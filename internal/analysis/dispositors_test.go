@@ -0,0 +1,91 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"testing"
+)
+
+func createDispositorChart(lonPositions []float64) domain.FullChartResponse {
+	points := make([]domain.PointPosResult, 0, len(lonPositions))
+	for i, lon := range lonPositions {
+		points = append(points, domain.PointPosResult{Point: domain.ChartPoint(i), LonPos: lon})
+	}
+	return domain.FullChartResponse{Points: points}
+}
+
+func TestCalcDispositorsTraditional(t *testing.T) {
+	// Sun Leo, Moon Aries, Mercury Leo, Venus Virgo, Mars Cancer, Jupiter Sagittarius, Saturn Taurus
+	chart := createDispositorChart([]float64{135.0, 10.0, 140.0, 160.0, 100.0, 250.0, 40.0})
+	dc := DispositorsCalculation{}
+	result, err := dc.CalcDispositors(chart, domain.RulershipTraditional)
+	if err != nil {
+		t.Fatalf("CalcDispositors returned unexpected error %v", err)
+	}
+	if len(result.FinalDispositors) != 2 || result.FinalDispositors[0] != domain.Sun ||
+		result.FinalDispositors[1] != domain.Jupiter {
+		t.Errorf("CalcDispositors expected Sun and Jupiter as final dispositors, got %v", result.FinalDispositors)
+	}
+	if len(result.Loops) != 1 || len(result.Loops[0]) != 2 || result.Loops[0][0] != domain.Moon ||
+		result.Loops[0][1] != domain.Mars {
+		t.Errorf("CalcDispositors expected a loop of Moon and Mars, got %v", result.Loops)
+	}
+	expectedDepths := []int{0, 0, 1, 2, 0, 0, 3}
+	for i, depth := range expectedDepths {
+		if result.Nodes[i].Depth != depth {
+			t.Errorf("CalcDispositors expected depth %d for %v, got %d", depth, result.Nodes[i].Point, result.Nodes[i].Depth)
+		}
+	}
+	sun := result.Nodes[0]
+	if len(sun.Disposes) != 1 || sun.Disposes[0] != domain.Mercury {
+		t.Errorf("CalcDispositors expected Sun to dispose only Mercury, got %v", sun.Disposes)
+	}
+	if len(result.MutualReceptions) != 1 || result.MutualReceptions[0].Reception != domain.ReceptionDomicile {
+		t.Errorf("CalcDispositors expected a mutual reception by domicile, got %v", result.MutualReceptions)
+	}
+}
+
+func TestCalcDispositorsExaltation(t *testing.T) {
+	// Sun Capricorn, Mars Aries, other planets in domicile
+	chart := createDispositorChart([]float64{280.0, 100.0, 70.0, 40.0, 10.0, 250.0, 290.0})
+	dc := DispositorsCalculation{}
+	result, err := dc.CalcDispositors(chart, domain.RulershipTraditional)
+	if err != nil {
+		t.Fatalf("CalcDispositors returned unexpected error %v", err)
+	}
+	if len(result.MutualReceptions) != 1 || result.MutualReceptions[0].Reception != domain.ReceptionExaltation ||
+		result.MutualReceptions[0].Point1 != domain.Sun || result.MutualReceptions[0].Point2 != domain.Mars {
+		t.Errorf("CalcDispositors expected a mutual reception by exaltation for Sun and Mars, got %v",
+			result.MutualReceptions)
+	}
+}
+
+func TestCalcDispositorsModernMissingPlanet(t *testing.T) {
+	chart := createDispositorChart([]float64{135.0, 10.0, 140.0, 160.0, 100.0, 250.0, 40.0})
+	dc := DispositorsCalculation{}
+	if _, err := dc.CalcDispositors(chart, domain.RulershipModern); err == nil {
+		t.Errorf("CalcDispositors expected error for missing modern planets")
+	}
+}
+
+func TestCalcDispositorsModern(t *testing.T) {
+	// Sun Scorpio, Pluto Aries, Mars Aries: Sun -> Pluto -> Mars (final)
+	chart := createDispositorChart([]float64{220.0, 100.0, 70.0, 40.0, 10.0, 250.0, 290.0, 310.0, 340.0, 5.0})
+	dc := DispositorsCalculation{}
+	result, err := dc.CalcDispositors(chart, domain.RulershipModern)
+	if err != nil {
+		t.Fatalf("CalcDispositors returned unexpected error %v", err)
+	}
+	if result.Nodes[0].Dispositor != domain.Pluto || result.Nodes[0].Depth != 2 {
+		t.Errorf("CalcDispositors expected Pluto as dispositor of the Sun at depth 2, got %v", result.Nodes[0])
+	}
+	if len(result.FinalDispositors) != 8 || len(result.Loops) != 0 {
+		t.Errorf("CalcDispositors expected 8 final dispositors, got %v", result.FinalDispositors)
+	}
+}
@@ -0,0 +1,157 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"errors"
+	"math"
+	"sort"
+)
+
+// DistributionCalculator calculates the distribution of the points over elements, modalities, hemispheres and
+// quadrants, and the chart shape.
+type DistributionCalculator interface {
+	CalcDistribution(chart domain.FullChartResponse, cfgPoints []domain.ConfigPoint) (domain.ChartDistribution, error)
+}
+
+type DistributionCalculation struct{}
+
+func NewDistributionCalculation() DistributionCalculator {
+	return DistributionCalculation{}
+}
+
+// Empty arcs that define the chart shapes. The shapes are checked in the sequence of the constants, the first match
+// defines the shape. Two empty arcs of at least GroupEmptyArc define a seesaw, three or more define a splay.
+// If none of the shapes matches, the shape is a splash.
+const (
+	BundleEmptyArc     = 240.0 // all planets within a trine
+	BowlEmptyArc       = 180.0 // all planets within an opposition
+	BucketEmptyArc     = 180.0 // all planets, except the handle, within an opposition
+	LocomotiveEmptyArc = 120.0 // all planets within two trines
+	GroupEmptyArc      = 60.0  // empty arc between groups of planets
+)
+
+// CalcDistribution calculates the distribution for the points in the chart. The weights are taken from cfgPoints,
+// a point that is not in cfgPoints has weight 1.0. The ascendant and the MC are added to the elements and modalities
+// if they are used in cfgPoints.
+// PRE chart contains at least 2 planets (Sun .. Pluto)
+// PRE for all points and angles: 0.0 <= LonPos < 360.0
+// POST no errors -> returns the distribution
+// POST errors: returns empty distribution and error
+func (dc DistributionCalculation) CalcDistribution(chart domain.FullChartResponse, cfgPoints []domain.ConfigPoint) (domain.ChartDistribution, error) {
+	weights := make(map[domain.ChartPoint]float64)
+	used := make(map[domain.ChartPoint]bool)
+	for _, cfgPoint := range cfgPoints {
+		weights[cfgPoint.ActualPoint] = cfgPoint.DistributionWeight
+		used[cfgPoint.ActualPoint] = cfgPoint.IsUsed
+	}
+	weightOf := func(point domain.ChartPoint) float64 {
+		if weight, found := weights[point]; found {
+			return weight
+		}
+		return 1.0
+	}
+	var distr domain.ChartDistribution
+	planetLons := make([]float64, 0)
+	for _, point := range chart.Points {
+		weight := weightOf(point.Point)
+		addToSignDistribution(&distr, point.LonPos, weight)
+		addToQuadrants(&distr, point.LonPos, chart.Asc.LonPos, chart.Mc.LonPos, weight)
+		if point.Point >= domain.Sun && point.Point <= domain.Pluto {
+			planetLons = append(planetLons, point.LonPos)
+		}
+	}
+	for _, angle := range []struct {
+		point domain.ChartPoint
+		lon   float64
+	}{{domain.Ascendant, chart.Asc.LonPos}, {domain.Mc, chart.Mc.LonPos}} {
+		if used[angle.point] {
+			addToSignDistribution(&distr, angle.lon, weightOf(angle.point))
+		}
+	}
+	distr.Northern = distr.Quadrants[0] + distr.Quadrants[1]
+	distr.Southern = distr.Quadrants[2] + distr.Quadrants[3]
+	distr.Eastern = distr.Quadrants[3] + distr.Quadrants[0]
+	distr.Western = distr.Quadrants[1] + distr.Quadrants[2]
+	shape, err := chartShape(planetLons)
+	if err != nil {
+		return domain.ChartDistribution{}, err
+	}
+	distr.Shape = shape
+	return distr, nil
+}
+
+func addToSignDistribution(distr *domain.ChartDistribution, lon, weight float64) {
+	sign := signOfPosition(lon)
+	distr.Elements[int(sign)%4] += weight
+	distr.Modalities[int(sign)%3] += weight
+}
+
+// addToQuadrants adds the weight to the quadrant that contains lon. The quadrants are defined by the angles:
+// ascendant, IC, descendant and MC, in the sequence of the zodiac.
+func addToQuadrants(distr *domain.ChartDistribution, lon, asc, mc, weight float64) {
+	arcToIc := arcForward(asc, mc+180.0)
+	arcToMc := arcForward(asc, mc)
+	arc := arcForward(asc, lon)
+	switch {
+	case arc < arcToIc:
+		distr.Quadrants[0] += weight
+	case arc < 180.0:
+		distr.Quadrants[1] += weight
+	case arc < arcToMc:
+		distr.Quadrants[2] += weight
+	default:
+		distr.Quadrants[3] += weight
+	}
+}
+
+// chartShape defines the shape according to Jones, using the empty arcs between the planets.
+func chartShape(lons []float64) (domain.ChartShape, error) {
+	if len(lons) < 2 {
+		return 0, errors.New("not enough planets to define a chart shape")
+	}
+	sorted := make([]float64, len(lons))
+	copy(sorted, lons)
+	sort.Float64s(sorted)
+	nrOfPlanets := len(sorted)
+	emptyArcs := make([]float64, nrOfPlanets)
+	maxEmptyArc := 0.0
+	for i := range sorted {
+		emptyArcs[i] = arcForward(sorted[i], sorted[(i+1)%nrOfPlanets])
+		maxEmptyArc = math.Max(maxEmptyArc, emptyArcs[i])
+	}
+	switch {
+	case maxEmptyArc >= BundleEmptyArc:
+		return domain.ShapeBundle, nil
+	case maxEmptyArc >= BowlEmptyArc:
+		return domain.ShapeBowl, nil
+	}
+	for i := range emptyArcs { // a handle is a single planet between two empty arcs, that together form a bowl
+		if emptyArcs[(i+nrOfPlanets-1)%nrOfPlanets]+emptyArcs[i] >= BucketEmptyArc {
+			return domain.ShapeBucket, nil
+		}
+	}
+	if maxEmptyArc >= LocomotiveEmptyArc {
+		return domain.ShapeLocomotive, nil
+	}
+	nrOfGroups := 0
+	for _, arc := range emptyArcs {
+		if arc >= GroupEmptyArc {
+			nrOfGroups++
+		}
+	}
+	switch {
+	case nrOfGroups == 2:
+		return domain.ShapeSeesaw, nil
+	case nrOfGroups > 2:
+		return domain.ShapeSplay, nil
+	default:
+		return domain.ShapeSplash, nil
+	}
+}
@@ -0,0 +1,90 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+func TestCalcDistribution(t *testing.T) {
+	chart := domain.FullChartResponse{
+		Points: []domain.PointPosResult{
+			{Point: domain.Sun, LonPos: 10.0},     // Aries, quadrant 4
+			{Point: domain.Moon, LonPos: 100.0},   // Cancer, quadrant 1
+			{Point: domain.Mercury, LonPos: 50.0}, // Taurus, quadrant 1
+			{Point: domain.Chiron, LonPos: 200.0}, // Libra, quadrant 2
+		},
+		Asc: domain.HousePosResult{LonPos: 45.0},  // Taurus
+		Mc:  domain.HousePosResult{LonPos: 315.0}, // Aquarius
+	}
+	cfgPoints := []domain.ConfigPoint{
+		{ActualPoint: domain.Sun, IsUsed: true, DistributionWeight: 2.0},
+		{ActualPoint: domain.Moon, IsUsed: true, DistributionWeight: 2.0},
+		{ActualPoint: domain.Mercury, IsUsed: true, DistributionWeight: 1.0},
+		{ActualPoint: domain.Ascendant, IsUsed: true, DistributionWeight: 2.0},
+		{ActualPoint: domain.Mc, IsUsed: false, DistributionWeight: 1.0},
+	}
+	dc := DistributionCalculation{}
+	result, err := dc.CalcDistribution(chart, cfgPoints)
+	if err != nil {
+		t.Fatalf("CalcDistribution returned unexpected error %v", err)
+	}
+	expElements := [4]float64{2.0, 3.0, 1.0, 2.0} // Chiron is not configured and has weight 1.0
+	expModalities := [3]float64{5.0, 3.0, 0.0}
+	expQuadrants := [4]float64{3.0, 1.0, 0.0, 2.0}
+	for i := range expElements {
+		if math.Abs(result.Elements[i]-expElements[i]) > delta {
+			t.Errorf("CalcDistribution expected %f for element %d, got %f", expElements[i], i, result.Elements[i])
+		}
+	}
+	for i := range expModalities {
+		if math.Abs(result.Modalities[i]-expModalities[i]) > delta {
+			t.Errorf("CalcDistribution expected %f for modality %d, got %f", expModalities[i], i, result.Modalities[i])
+		}
+	}
+	for i := range expQuadrants {
+		if math.Abs(result.Quadrants[i]-expQuadrants[i]) > delta {
+			t.Errorf("CalcDistribution expected %f for quadrant %d, got %f", expQuadrants[i], i+1, result.Quadrants[i])
+		}
+	}
+	if math.Abs(result.Northern-4.0) > delta || math.Abs(result.Eastern-5.0) > delta {
+		t.Errorf("CalcDistribution expected northern 4.0 and eastern 5.0, got %v", result)
+	}
+}
+
+func TestChartShape(t *testing.T) {
+	tests := []struct {
+		lons     []float64
+		expected domain.ChartShape
+	}{
+		{[]float64{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}, domain.ShapeBundle},
+		{[]float64{0, 20, 40, 60, 80, 100, 120, 140, 160, 180}, domain.ShapeBowl},
+		{[]float64{0, 20, 40, 60, 80, 100, 120, 140, 160, 270}, domain.ShapeBucket},
+		{[]float64{0, 24, 48, 72, 96, 120, 144, 168, 192, 216}, domain.ShapeLocomotive},
+		{[]float64{0, 20, 40, 60, 80, 180, 200, 220, 240, 260}, domain.ShapeSeesaw},
+		{[]float64{0, 10, 20, 110, 120, 130, 220, 230, 240, 250}, domain.ShapeSplay},
+		{[]float64{0, 36, 72, 108, 144, 180, 216, 252, 288, 324}, domain.ShapeSplash},
+	}
+	for _, test := range tests {
+		result, err := chartShape(test.lons)
+		if err != nil {
+			t.Fatalf("chartShape returned unexpected error %v", err)
+		}
+		if result != test.expected {
+			t.Errorf("chartShape expected %v for %v, got %v", test.expected, test.lons, result)
+		}
+	}
+}
+
+func TestChartShapeNotEnoughPlanets(t *testing.T) {
+	if _, err := chartShape([]float64{10.0}); err == nil {
+		t.Errorf("chartShape expected error for a single planet")
+	}
+}
@@ -10,11 +10,14 @@ package analysis
 import (
 	"enigma-ar/domain"
 	"enigma-ar/internal/calc"
+	"math"
 )
 
 // HarmonicsCalculator calculates harmonics.
 type HarmonicsCalculator interface {
 	CalcHarmonics(actPositions []domain.SinglePosition, harmonicNr float64) ([]domain.SinglePosition, error)
+	CalcHarmonicChart(actPositions []domain.SinglePosition, harmonicNr float64, orb float64) (domain.HarmonicChart, error)
+	CalcHarmonicConjunctions(actPositions []domain.SinglePosition, minHarmonic int, maxHarmonic int, orb float64) ([]domain.HarmonicConjunctions, error)
 }
 
 type HarmonicsCalculation struct{}
@@ -36,3 +39,105 @@ func (h HarmonicsCalculation) CalcHarmonics(actPositions []domain.SinglePosition
 	}
 	return result, nil
 }
+
+// CalcHarmonicChart calculates a harmonic chart with the conjunctions and oppositions between the harmonic positions.
+// The orb is used in the harmonic chart, the corresponding orb in the radix is orb / harmonicNr.
+func (h HarmonicsCalculation) CalcHarmonicChart(actPositions []domain.SinglePosition, harmonicNr float64, orb float64) (domain.HarmonicChart, error) {
+	harmonicPositions, err := h.CalcHarmonics(actPositions, harmonicNr)
+	if err != nil {
+		return domain.HarmonicChart{}, err
+	}
+	aspects := make([]domain.HarmonicAspect, 0)
+	for i := 0; i < len(harmonicPositions); i++ {
+		for j := i + 1; j < len(harmonicPositions); j++ {
+			distance := shortestArc(harmonicPositions[i].Position, harmonicPositions[j].Position)
+			conjunction := distance <= orb
+			if !conjunction && 180.0-distance > orb {
+				continue
+			}
+			actualOrb := distance
+			if !conjunction {
+				actualOrb = 180.0 - distance
+			}
+			radixDifference := actPositions[i].Position - actPositions[j].Position
+			radixArc := radixAspect(radixDifference, harmonicNr, conjunction)
+			aspect, found := aspectForArc(radixArc)
+			aspects = append(aspects, domain.HarmonicAspect{
+				Pos1:          harmonicPositions[i],
+				Pos2:          harmonicPositions[j],
+				Conjunction:   conjunction,
+				Orb:           actualOrb,
+				RadixDistance: shortestArc(radixDifference, 0.0),
+				RadixArc:      radixArc,
+				RadixAspect:   aspect,
+				AspectFound:   found,
+			})
+		}
+	}
+	return domain.HarmonicChart{
+		Harmonic:  harmonicNr,
+		Positions: harmonicPositions,
+		Aspects:   aspects,
+	}, nil
+}
+
+// CalcHarmonicConjunctions scans the harmonics minHarmonic .. maxHarmonic and returns, for each pair of points that
+// is conjunct in at least one harmonic, the harmonics with a conjunction. The orb is used in the harmonic chart.
+func (h HarmonicsCalculation) CalcHarmonicConjunctions(actPositions []domain.SinglePosition, minHarmonic int, maxHarmonic int, orb float64) ([]domain.HarmonicConjunctions, error) {
+	result := make([]domain.HarmonicConjunctions, 0)
+	for i := 0; i < len(actPositions); i++ {
+		for j := i + 1; j < len(actPositions); j++ {
+			matches := make([]domain.HarmonicMatch, 0)
+			for harmonic := minHarmonic; harmonic <= maxHarmonic; harmonic++ {
+				harmonicDistance, err := calc.ValueToRange((actPositions[i].Position-actPositions[j].Position)*float64(harmonic), 0.0, 360.0)
+				if err != nil {
+					return nil, err
+				}
+				actualOrb := math.Min(harmonicDistance, 360.0-harmonicDistance)
+				if actualOrb <= orb {
+					matches = append(matches, domain.HarmonicMatch{Harmonic: harmonic, Orb: actualOrb})
+				}
+			}
+			if len(matches) > 0 {
+				result = append(result, domain.HarmonicConjunctions{
+					Point1:  actPositions[i].Id,
+					Point2:  actPositions[j].Id,
+					Matches: matches,
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+// shortestArc returns the shortest distance between two positions, in the range 0.0 .. 180.0.
+func shortestArc(pos1, pos2 float64) float64 {
+	distance := math.Mod(math.Abs(pos1-pos2), 360.0)
+	return math.Min(distance, 360.0-distance)
+}
+
+// radixAspect returns the exact arc in the radix that results in a conjunction or opposition in the harmonic chart.
+// A conjunction occurs at multiples of 360 / harmonicNr, an opposition halfway between these multiples. The
+// difference is not reduced before the calculation as that would change the result for fractional harmonics.
+func radixAspect(radixDifference, harmonicNr float64, conjunction bool) float64 {
+	unit := 360.0 / harmonicNr
+	var aspect float64
+	if conjunction {
+		aspect = math.Round(radixDifference/unit) * unit
+	} else {
+		aspect = (math.Round(radixDifference/unit-0.5) + 0.5) * unit
+	}
+	return shortestArc(aspect, 0.0)
+}
+
+// aspectForArc returns the aspect from AllAspects with a distance that equals arc. The margin allows for the rounded
+// distances of aspects that are not a whole number of degrees, like the septile.
+func aspectForArc(arc float64) (domain.Aspect, bool) {
+	const ArcMargin = 1e-6
+	for _, aspect := range domain.AllAspects() {
+		if math.Abs(aspect.Distance-arc) < ArcMargin {
+			return domain.Aspect(aspect.Key), true
+		}
+	}
+	return 0, false
+}
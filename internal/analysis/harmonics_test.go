@@ -48,3 +48,74 @@ func TestCalcHarmonicsHappyFlow(t *testing.T) {
 		}
 	}
 }
+
+func TestCalcHarmonicChart(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 82.5}, // quintile with Sun
+		{Id: domain.Mars, Position: 46.0}, // decile with Sun
+	}
+	hCalc := NewHarmonicsCalculation()
+	result, err := hCalc.CalcHarmonicChart(positions, 5.0, 3.0)
+	if err != nil {
+		t.Fatalf("CalcHarmonicChart returned unexpected error %v", err)
+	}
+	if result.Harmonic != 5.0 || len(result.Positions) != 3 {
+		t.Fatalf("CalcHarmonicChart expected 3 positions for harmonic 5, got %v", result)
+	}
+	if len(result.Aspects) != 3 {
+		t.Fatalf("CalcHarmonicChart expected 3 aspects, got %d: %v", len(result.Aspects), result.Aspects)
+	}
+	sunMoon := result.Aspects[0]
+	if !sunMoon.Conjunction || math.Abs(sunMoon.Orb-2.5) > 1e-8 || math.Abs(sunMoon.RadixArc-72.0) > 1e-8 ||
+		math.Abs(sunMoon.RadixDistance-72.5) > 1e-8 || !sunMoon.AspectFound || sunMoon.RadixAspect != domain.Quintile {
+		t.Errorf("CalcHarmonicChart expected conjunction for quintile with orb 2.5, got %v", sunMoon)
+	}
+	sunMars := result.Aspects[1]
+	if sunMars.Conjunction || math.Abs(sunMars.Orb) > 1e-8 || math.Abs(sunMars.RadixArc-36.0) > 1e-8 ||
+		!sunMars.AspectFound || sunMars.RadixAspect != domain.SemiQuintile {
+		t.Errorf("CalcHarmonicChart expected opposition for decile, got %v", sunMars)
+	}
+}
+
+func TestCalcHarmonicConjunctions(t *testing.T) {
+	var positions = []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 82.0},
+		{Id: domain.Mars, Position: 46.0},
+	}
+	hCalc := NewHarmonicsCalculation()
+	result, err := hCalc.CalcHarmonicConjunctions(positions, 1, 12, 2.0)
+	if err != nil {
+		t.Fatalf("CalcHarmonicConjunctions returned unexpected error %v", err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("CalcHarmonicConjunctions expected 3 pairs, got %d: %v", len(result), result)
+	}
+	sunMoon := result[0]
+	if sunMoon.Point1 != domain.Sun || sunMoon.Point2 != domain.Moon || len(sunMoon.Matches) != 2 ||
+		sunMoon.Matches[0].Harmonic != 5 || sunMoon.Matches[1].Harmonic != 10 {
+		t.Errorf("CalcHarmonicConjunctions expected Sun and Moon conjunct in harmonics 5 and 10, got %v", sunMoon)
+	}
+	sunMars := result[1]
+	if len(sunMars.Matches) != 1 || sunMars.Matches[0].Harmonic != 10 || math.Abs(sunMars.Matches[0].Orb) > 1e-8 {
+		t.Errorf("CalcHarmonicConjunctions expected Sun and Mars conjunct in harmonic 10, got %v", sunMars)
+	}
+}
+
+func TestRadixAspectFractionalHarmonic(t *testing.T) {
+	// harmonic 1.5: conjunctions at multiples of 240 degrees
+	if math.Abs(radixAspect(-238.0, 1.5, true)-120.0) > 1e-8 {
+		t.Errorf("radixAspect expected 120.0, got %f", radixAspect(-238.0, 1.5, true))
+	}
+}
+
+func TestAspectForArc(t *testing.T) {
+	aspect, found := aspectForArc(360.0 / 7.0)
+	if !found || aspect != domain.Septile {
+		t.Errorf("aspectForArc expected septile, got %d, found %v", aspect, found)
+	}
+	if _, found = aspectForArc(24.0); found {
+		t.Errorf("aspectForArc expected no aspect for 24.0")
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/calc"
+	"enigma-ar/internal/calc/mathextra"
+	"math"
+)
+
+// HarmonicSpectrumCalculator calculates the amplitude and phase for a range of harmonics, in the way of John Addey.
+type HarmonicSpectrumCalculator interface {
+	CalcSpectrum(positions []float64, maxHarmonic int) ([]domain.HarmonicComponent, error)
+}
+
+type HarmonicSpectrumCalculation struct{}
+
+func NewHarmonicSpectrumCalculation() HarmonicSpectrumCalculator {
+	return HarmonicSpectrumCalculation{}
+}
+
+// CalcSpectrum calculates the harmonic components for the harmonics 1 .. maxHarmonic. The positions can be the
+// positions of several points in one chart or the positions of one point in a dataset of charts.
+// The significance is tested with the Rayleigh test for uniformity, the p-value uses the approximation by Zar.
+// PRE length positions >= 2
+// PRE maxHarmonic >= 1
+// POST no errors -> returns components, sorted by harmonic. Errors: returns nil and error
+func (hsc HarmonicSpectrumCalculation) CalcSpectrum(positions []float64, maxHarmonic int) ([]domain.HarmonicComponent, error) {
+	count := float64(len(positions))
+	components := make([]domain.HarmonicComponent, 0, maxHarmonic)
+	for harmonic := 1; harmonic <= maxHarmonic; harmonic++ {
+		var sumCos, sumSin float64
+		for _, pos := range positions {
+			harmonicRad := mathextra.DegToRad(pos * float64(harmonic))
+			sumCos += math.Cos(harmonicRad)
+			sumSin += math.Sin(harmonicRad)
+		}
+		amplitude := math.Sqrt(sumCos*sumCos+sumSin*sumSin) / count
+		phase, err := calc.ValueToRange(mathextra.RadToDeg(math.Atan2(sumSin, sumCos)), 0.0, 360.0)
+		if err != nil {
+			return nil, err
+		}
+		z := count * amplitude * amplitude
+		components = append(components, domain.HarmonicComponent{
+			Harmonic:  harmonic,
+			Amplitude: amplitude,
+			Phase:     phase,
+			RayleighZ: z,
+			PValue:    rayleighPValue(z, count),
+		})
+	}
+	return components, nil
+}
+
+// rayleighPValue approximates the probability for the Rayleigh statistic z and sample size n.
+func rayleighPValue(z, n float64) float64 {
+	p := math.Exp(-z) * (1.0 + (2.0*z-z*z)/(4.0*n) -
+		(24.0*z-132.0*z*z+76.0*z*z*z-9.0*z*z*z*z)/(288.0*n*n))
+	return math.Max(0.0, math.Min(1.0, p))
+}
@@ -0,0 +1,63 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCalcSpectrumFourthHarmonic(t *testing.T) {
+	// four positions in a square: exact conjunction in the fourth harmonic, zero amplitude in the first harmonic
+	positions := []float64{10.0, 100.0, 190.0, 280.0}
+	hsc := HarmonicSpectrumCalculation{}
+	result, err := hsc.CalcSpectrum(positions, 4)
+	if err != nil {
+		t.Fatalf("CalcSpectrum returned unexpected error %v", err)
+	}
+	if len(result) != 4 {
+		t.Fatalf("CalcSpectrum expected 4 components, got %d", len(result))
+	}
+	if result[0].Harmonic != 1 || result[0].Amplitude > delta {
+		t.Errorf("CalcSpectrum expected zero amplitude for harmonic 1, got %v", result[0])
+	}
+	fourth := result[3]
+	if fourth.Harmonic != 4 || math.Abs(fourth.Amplitude-1.0) > delta || math.Abs(fourth.Phase-40.0) > delta {
+		t.Errorf("CalcSpectrum expected amplitude 1.0 and phase 40.0 for harmonic 4, got %v", fourth)
+	}
+	if math.Abs(fourth.RayleighZ-4.0) > delta {
+		t.Errorf("CalcSpectrum expected Rayleigh Z of 4.0, got %f", fourth.RayleighZ)
+	}
+}
+
+func TestCalcSpectrumPhaseAndAmplitude(t *testing.T) {
+	positions := []float64{350.0, 10.0}
+	hsc := HarmonicSpectrumCalculation{}
+	result, err := hsc.CalcSpectrum(positions, 1)
+	if err != nil {
+		t.Fatalf("CalcSpectrum returned unexpected error %v", err)
+	}
+	expected := math.Cos(10.0 * math.Pi / 180.0)
+	if math.Abs(result[0].Amplitude-expected) > delta || math.Abs(result[0].Phase) > delta {
+		t.Errorf("CalcSpectrum expected amplitude %f and phase 0.0, got %v", expected, result[0])
+	}
+}
+
+func TestRayleighPValue(t *testing.T) {
+	// large sample, the approximation approaches exp(-z)
+	if math.Abs(rayleighPValue(3.0, 1_000_000.0)-math.Exp(-3.0)) > 0.0001 {
+		t.Errorf("rayleighPValue expected %f, got %f", math.Exp(-3.0), rayleighPValue(3.0, 1_000_000.0))
+	}
+	// critical value for p = 0.05 and n = 10 is 2.910 (Zar, table B.34)
+	if math.Abs(rayleighPValue(2.910, 10.0)-0.05) > 0.001 {
+		t.Errorf("rayleighPValue expected 0.05, got %f", rayleighPValue(2.910, 10.0))
+	}
+	if rayleighPValue(0.0, 10.0) != 1.0 {
+		t.Errorf("rayleighPValue expected 1.0 for z = 0.0, got %f", rayleighPValue(0.0, 10.0))
+	}
+}
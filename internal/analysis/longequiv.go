@@ -30,19 +30,9 @@ func (lec LongEquivCalculation) CalcEquivalents(positions []domain.DoublePositio
 	longitudeEquivalents := make([]domain.SinglePosition, 0)
 
 	for _, longDeclPos := range positions {
-		radixDeclination := longDeclPos.Position2
-		declination := radixDeclination
+		declination := mirrorDeclination(longDeclPos.Position2, obliquity) // handle OOB
 		longitude := longDeclPos.Position1
 
-		if math.Abs(radixDeclination) > obliquity { // OOB
-			oobPart := math.Abs(radixDeclination) - obliquity
-			if radixDeclination > 0 {
-				declination = obliquity - oobPart
-			} else {
-				declination = oobPart - obliquity
-			}
-		}
-
 		candidate1 := conversion.DeclinationToLongitude(obliquity, declination)
 		if candidate1 < 0.0 {
 			candidate1 += 360.0
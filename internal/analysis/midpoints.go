@@ -9,13 +9,20 @@ package analysis
 
 import (
 	"enigma-ar/domain"
+	"fmt"
 	"math"
+	"sort"
 )
 
-// MidpointsCalculator calculates midpoints in longitude (or in ra)
+// MidpointsCalculator calculates midpoints in longitude, right ascension or azimuth
 type MidpointsCalculator interface {
 	CalcMidpoints(points []domain.SinglePosition) ([]domain.Midpoint, error)
 	CalcOccupiedMidpoints(points []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.OccupiedMidpoint, error)
+	CalcInterMidpoints(pointsA []domain.SinglePosition, pointsB []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.OccupiedMidpoint, error)
+	CalcPlanetaryPictures(points []domain.SinglePosition, pictureType domain.PlanetaryPictureType, dial domain.MpDial, orb float64) ([]domain.PlanetaryPicture, error)
+	CalcMidpointTrees(points []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.MidpointTree, error)
+	CalcDialSort(points []domain.SinglePosition, dial domain.MpDial) ([]domain.DialSortItem, error)
+	CalcFramedMidpoints(points []domain.PointPosResult, frame domain.CoordinateSystem, dial domain.MpDial, orb float64) ([]domain.FramedMidpoint, error)
 }
 
 type MidpointsCalculation struct{}
@@ -51,15 +58,250 @@ func (mc MidpointsCalculation) CalcMidpoints(points []domain.SinglePosition) ([]
 // POST errors: returns empty slice and error
 func (mc MidpointsCalculation) CalcOccupiedMidpoints(points []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.OccupiedMidpoint, error) {
 	occMidpoints := make([]domain.OccupiedMidpoint, 0)
-	pointsInDial := make([]domain.SinglePosition, 0)
+	dialSize := sizeOfDial(dial)
+	pointsInDial := reduceToDial(points, dialSize)
+	for i := 0; i < len(pointsInDial); i++ { // first point
+		for j := i + 1; j < len(pointsInDial); j++ { // second point
+			mp := constructEffectiveMidpoint(pointsInDial[i], pointsInDial[j], dialSize) // calc midpoint
+			for k := 0; k < len(pointsInDial); k++ {
+				if occMp, found := occupation(pointsInDial[i], pointsInDial[j], pointsInDial[k], mp, dialSize, orb); found {
+					occMidpoints = append(occMidpoints, occMp)
+				}
+			}
+		}
+	}
+	return occMidpoints, nil
+}
+
+// CalcInterMidpoints calculates the midpoints in chart B that are occupied by points in chart A.
+// PRE length pointsA >= 1
+// PRE length pointsB >= 2
+// PRE for all positions : 0.0 <= position < 360.0
+// PRE 0.0 < orb <= 10.0
+// POST no errors -> returns slice of occupied midpoints, the focuspoints are from chart A
+// POST errors: returns empty slice and error
+func (mc MidpointsCalculation) CalcInterMidpoints(pointsA []domain.SinglePosition, pointsB []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.OccupiedMidpoint, error) {
+	occMidpoints := make([]domain.OccupiedMidpoint, 0)
+	dialSize := sizeOfDial(dial)
+	pointsAInDial := reduceToDial(pointsA, dialSize)
+	pointsBInDial := reduceToDial(pointsB, dialSize)
+	for i := 0; i < len(pointsBInDial); i++ {
+		for j := i + 1; j < len(pointsBInDial); j++ {
+			mp := constructEffectiveMidpoint(pointsBInDial[i], pointsBInDial[j], dialSize)
+			for _, focus := range pointsAInDial {
+				if occMp, found := occupation(pointsBInDial[i], pointsBInDial[j], focus, mp, dialSize, orb); found {
+					occMidpoints = append(occMidpoints, occMp)
+				}
+			}
+		}
+	}
+	return occMidpoints, nil
+}
 
-	// reduce all positions to size of dial
+// CalcPlanetaryPictures calculates planetary pictures, all points are different points.
+// For PictureSum, A and B are interchangeable and each combination is returned once. As A+B-C = D is equivalent to
+// A+B = C+D, each balance of two pairs results in four sums.
+// For PictureBalance, each combination of two pairs is returned once.
+// The orb is the distance between the sensitive point and D, which is the same as the difference between the sums.
+// PRE length points >= 4
+// PRE for all positions : 0.0 <= position < 360.0
+// PRE 0.0 < orb <= 10.0
+// POST no errors -> returns slice of planetary pictures, sorted by exactness, the most exact picture first
+// POST errors: returns empty slice and error
+func (mc MidpointsCalculation) CalcPlanetaryPictures(points []domain.SinglePosition, pictureType domain.PlanetaryPictureType, dial domain.MpDial, orb float64) ([]domain.PlanetaryPicture, error) {
+	pictures := make([]domain.PlanetaryPicture, 0)
+	dialSize := sizeOfDial(dial)
+	pointsInDial := reduceToDial(points, dialSize)
+	count := len(pointsInDial)
+	for a := 0; a < count; a++ {
+		for b := a + 1; b < count; b++ {
+			sum := math.Mod(pointsInDial[a].Position+pointsInDial[b].Position, dialSize)
+			for c := 0; c < count; c++ {
+				if c == a || c == b || (pictureType == domain.PictureBalance && c <= a) {
+					continue
+				}
+				for d := 0; d < count; d++ {
+					if d == a || d == b || d == c || (pictureType == domain.PictureBalance && d <= c) {
+						continue
+					}
+					sensitivePoint := sum
+					if pictureType == domain.PictureSum {
+						sensitivePoint = math.Mod(sum-pointsInDial[c].Position+dialSize, dialSize)
+					}
+					actOrb := dialDistance(sensitivePoint, pointsInDial[c].Position+pointsInDial[d].Position, dialSize)
+					if pictureType == domain.PictureSum {
+						actOrb = dialDistance(sensitivePoint, pointsInDial[d].Position, dialSize)
+					}
+					if actOrb <= orb {
+						pictures = append(pictures, domain.PlanetaryPicture{
+							PictureType:    pictureType,
+							PointA:         pointsInDial[a],
+							PointB:         pointsInDial[b],
+							PointC:         pointsInDial[c],
+							PointD:         pointsInDial[d],
+							SensitivePoint: sensitivePoint,
+							ActualOrb:      actOrb,
+							Exactness:      (1 - (actOrb / orb)) * 100.0,
+						})
+					}
+				}
+			}
+		}
+	}
+	sort.SliceStable(pictures, func(i, j int) bool {
+		return pictures[i].Exactness > pictures[j].Exactness
+	})
+	return pictures, nil
+}
+
+// CalcMidpointTrees calculates a midpoint tree for each point, based on the occupied midpoints in the dial.
+// The occupation is defined by the angle in the 360 degree circle between the focus point and the midpoint.
+// PRE length points >= 3
+// PRE for all positions : 0.0 <= position < 360.0
+// PRE 0.0 < orb <= 10.0
+// POST no errors -> returns a tree for each point in the sequence of points, the midpoints are sorted by orb
+// POST errors: returns empty slice and error
+func (mc MidpointsCalculation) CalcMidpointTrees(points []domain.SinglePosition, dial domain.MpDial, orb float64) ([]domain.MidpointTree, error) {
+	occMidpoints, err := mc.CalcOccupiedMidpoints(points, dial, orb)
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[domain.ChartPoint]float64)
+	for _, point := range points {
+		positions[point.Id] = point.Position
+	}
+	trees := make([]domain.MidpointTree, 0, len(points))
+	for _, point := range points {
+		treeMidpoints := make([]domain.TreeMidpoint, 0)
+		for _, occMp := range occMidpoints {
+			if occMp.FocusPoint.Id != point.Id {
+				continue
+			}
+			mp360 := constructEffectiveMidpoint(
+				domain.SinglePosition{Position: positions[occMp.BaseMidpointPos1.Id]},
+				domain.SinglePosition{Position: positions[occMp.BaseMidpointPos2.Id]},
+				360.0)
+			treeMidpoints = append(treeMidpoints, domain.TreeMidpoint{
+				Midpoint:   occMp,
+				Occupation: occupationType(point.Position, mp360),
+			})
+		}
+		sort.SliceStable(treeMidpoints, func(i, j int) bool {
+			return treeMidpoints[i].Midpoint.ActualOrb < treeMidpoints[j].Midpoint.ActualOrb
+		})
+		trees = append(trees, domain.MidpointTree{FocusPoint: point, Midpoints: treeMidpoints})
+	}
+	return trees, nil
+}
+
+// CalcDialSort returns all points and all midpoints, sorted by their position in the dial.
+// PRE length points >= 2
+// PRE for all positions : 0.0 <= position < 360.0
+// POST no errors -> returns the sorted points and midpoints
+// POST errors: returns empty slice and error
+func (mc MidpointsCalculation) CalcDialSort(points []domain.SinglePosition, dial domain.MpDial) ([]domain.DialSortItem, error) {
+	midpoints, err := mc.CalcMidpoints(points)
+	if err != nil {
+		return nil, err
+	}
+	dialSize := sizeOfDial(dial)
+	items := make([]domain.DialSortItem, 0, len(points)+len(midpoints))
+	for _, point := range points {
+		items = append(items, domain.DialSortItem{
+			Point1:  point,
+			DialPos: math.Mod(point.Position, dialSize),
+		})
+	}
+	for _, mp := range midpoints {
+		items = append(items, domain.DialSortItem{
+			Point1:     mp.Point1,
+			Point2:     mp.Point2,
+			IsMidpoint: true,
+			DialPos:    math.Mod(mp.MidpointPos, dialSize),
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DialPos < items[j].DialPos
+	})
+	return items, nil
+}
+
+// CalcFramedMidpoints calculates occupied midpoints, measured in the given frame. Ecliptical uses longitude,
+// equatorial uses right ascension and horizontal uses azimuth.
+func (mc MidpointsCalculation) CalcFramedMidpoints(points []domain.PointPosResult, frame domain.CoordinateSystem, dial domain.MpDial, orb float64) ([]domain.FramedMidpoint, error) {
+	positions := make([]domain.SinglePosition, 0, len(points))
+	for _, point := range points {
+		pos, err := midpointFramePosition(point, frame)
+		if err != nil {
+			return nil, err
+		}
+		positions = append(positions, domain.SinglePosition{Id: point.Point, Position: pos})
+	}
+	occMidpoints, err := mc.CalcOccupiedMidpoints(positions, dial, orb)
+	if err != nil {
+		return nil, err
+	}
+	framedMidpoints := make([]domain.FramedMidpoint, 0, len(occMidpoints))
+	for _, occMp := range occMidpoints {
+		framedMidpoints = append(framedMidpoints, domain.FramedMidpoint{Midpoint: occMp, Frame: frame})
+	}
+	return framedMidpoints, nil
+}
+
+// midpointFramePosition returns the position of a point in the given frame.
+// Declination midpoints are handled by the DeclMidpointsCalculator and are not supported here.
+func midpointFramePosition(point domain.PointPosResult, frame domain.CoordinateSystem) (float64, error) {
+	switch frame {
+	case domain.CoordEcliptical:
+		return point.LonPos, nil
+	case domain.CoordEquatorial:
+		return point.RaPos, nil
+	case domain.CoordHorizontal:
+		return point.AzimPos, nil
+	default:
+		return 0.0, fmt.Errorf("frame %d is not supported for midpoints", frame)
+	}
+}
+
+// occupationType defines the occupation, using the nearest multiple of 11.25 degrees for the angle between the focus
+// point and the midpoint. Both sides of the midpoint axis are equivalent, so the angle is reduced to 0 ..< 180.
+func occupationType(focusPos, mpPos float64) domain.MidpointOccupation {
+	const HalfSemiOctile = 11.25
+	angle := math.Mod(math.Abs(focusPos-mpPos), 180.0)
+	steps := int(math.Round(angle/HalfSemiOctile)) % 16
+	switch {
+	case steps == 0:
+		return domain.OccupationDirect
+	case steps == 8:
+		return domain.OccupationSquare
+	case steps%4 == 0:
+		return domain.OccupationOctile
+	case steps%2 == 0:
+		return domain.OccupationSemiOctile
+	default:
+		return domain.OccupationHalfSemiOctile
+	}
+}
+
+// dialDistance returns the shortest distance between two positions in a dial.
+func dialDistance(pos1, pos2, dialSize float64) float64 {
+	distance := math.Mod(math.Abs(pos1-pos2), dialSize)
+	return math.Min(distance, dialSize-distance)
+}
+
+func sizeOfDial(dial domain.MpDial) float64 {
 	dialSize := 360.0
 	for i := 0; i < len(domain.AllMpDials()); i++ {
 		if domain.AllMpDials()[i].Key == int(dial) {
 			dialSize = domain.AllMpDials()[i].DialSize
 		}
 	}
+	return dialSize
+}
+
+// reduceToDial reduces all positions to the size of the dial
+func reduceToDial(points []domain.SinglePosition, dialSize float64) []domain.SinglePosition {
+	pointsInDial := make([]domain.SinglePosition, 0)
 	var tempPos float64
 	for i := 0; i < len(points); i++ {
 		tempPos = points[i].Position
@@ -71,35 +313,31 @@ func (mc MidpointsCalculation) CalcOccupiedMidpoints(points []domain.SinglePosit
 			Position: tempPos,
 		})
 	}
-	var actOrb float64
-	var exactness float64
-	for i := 0; i < len(pointsInDial); i++ { // first point
-		for j := i + 1; j < len(pointsInDial); j++ { // second point
-			mp := constructEffectiveMidpoint(pointsInDial[i], pointsInDial[j], dialSize) // calc midpoint
-			for k := 0; k < len(pointsInDial); k++ {
-				mpCandidatePos1 := pointsInDial[k].Position
-				mpCandidatePos2 := mpCandidatePos1 - (dialSize / 2.0)
-				if mpCandidatePos2 < 0.0 {
-					mpCandidatePos2 = mpCandidatePos1 + (dialSize / 2.0)
-				}
-				if math.Abs(mpCandidatePos1-mp) <= orb || math.Abs(mpCandidatePos2-mp) <= orb { // match
-					actOrb = math.Abs(mpCandidatePos1 - mp)
-					if math.Abs(mpCandidatePos2-mp) < actOrb {
-						actOrb = math.Abs(mpCandidatePos2 - mp)
-					}
-					exactness = (1 - (actOrb / orb)) * 100.0
-					occMidpoints = append(occMidpoints, domain.OccupiedMidpoint{
-						BaseMidpointPos1: pointsInDial[i],
-						BaseMidpointPos2: pointsInDial[j],
-						FocusPoint:       pointsInDial[k],
-						ActualOrb:        actOrb,
-						Exactness:        exactness,
-					})
-				}
-			}
+	return pointsInDial
+}
+
+// occupation checks if the focus point occupies the midpoint mp, or the opposite position in the dial.
+func occupation(base1, base2, focus domain.SinglePosition, mp, dialSize, orb float64) (domain.OccupiedMidpoint, bool) {
+	mpCandidatePos1 := focus.Position
+	mpCandidatePos2 := mpCandidatePos1 - (dialSize / 2.0)
+	if mpCandidatePos2 < 0.0 {
+		mpCandidatePos2 = mpCandidatePos1 + (dialSize / 2.0)
+	}
+	if math.Abs(mpCandidatePos1-mp) <= orb || math.Abs(mpCandidatePos2-mp) <= orb { // match
+		actOrb := math.Abs(mpCandidatePos1 - mp)
+		if math.Abs(mpCandidatePos2-mp) < actOrb {
+			actOrb = math.Abs(mpCandidatePos2 - mp)
 		}
+		exactness := (1 - (actOrb / orb)) * 100.0
+		return domain.OccupiedMidpoint{
+			BaseMidpointPos1: base1,
+			BaseMidpointPos2: base2,
+			FocusPoint:       focus,
+			ActualOrb:        actOrb,
+			Exactness:        exactness,
+		}, true
 	}
-	return occMidpoints, nil
+	return domain.OccupiedMidpoint{}, false
 }
 
 func constructEffectiveMidpoint(point1, point2 domain.SinglePosition, dialSize float64) float64 {
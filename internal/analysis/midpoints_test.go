@@ -222,3 +222,216 @@ func TestOccupiedMidpointDisc45(t *testing.T) {
 		}
 	}
 }
+
+func TestInterMidpointsHappyFlow(t *testing.T) {
+	pointsA := []domain.SinglePosition{
+		{Id: 0, Position: 40.5},  // on midpoint of B
+		{Id: 1, Position: 222.0}, // on opposite of midpoint of B
+		{Id: 2, Position: 130.0},
+	}
+	pointsB := []domain.SinglePosition{
+		{Id: 0, Position: 10.0},
+		{Id: 1, Position: 70.0},
+	}
+	mc := MidpointsCalculation{}
+	result, err := mc.CalcInterMidpoints(pointsA, pointsB, domain.Dial360, 2.0)
+	if err != nil {
+		t.Fatalf("CalcInterMidpoints returned unexpected error %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 occupied midpoints, got %d: %v", len(result), result)
+	}
+	if result[0].FocusPoint.Id != 0 || math.Abs(result[0].ActualOrb-0.5) > 1e-8 || math.Abs(result[0].Exactness-75.0) > 1e-8 {
+		t.Errorf("Expected point 0 on midpoint with orb 0.5, got %v", result[0])
+	}
+	if result[1].FocusPoint.Id != 1 || math.Abs(result[1].ActualOrb-2.0) > 1e-8 {
+		t.Errorf("Expected point 1 on midpoint with orb 2.0, got %v", result[1])
+	}
+	if result[1].BaseMidpointPos1.Position != 10.0 || result[1].BaseMidpointPos2.Position != 70.0 {
+		t.Errorf("Expected base midpoint of chart B, got %v", result[1])
+	}
+}
+
+func TestPlanetaryPicturesSum(t *testing.T) {
+	points := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 50.0},
+		{Id: domain.Mars, Position: 20.0},
+		{Id: domain.CupidoUra, Position: 41.0},
+	}
+	mc := MidpointsCalculation{}
+	result, err := mc.CalcPlanetaryPictures(points, domain.PictureSum, domain.Dial360, 1.5)
+	if err != nil {
+		t.Fatalf("CalcPlanetaryPictures returned unexpected error %v", err)
+	}
+	// Sun+Moon-Mars = Cupido, Sun+Moon-Cupido = Mars, Mars+Cupido-Sun = Moon, Mars+Cupido-Moon = Sun
+	if len(result) != 4 {
+		t.Fatalf("Expected 4 planetary pictures, got %d: %v", len(result), result)
+	}
+	first := result[0]
+	if first.PointA.Id != domain.Sun || first.PointB.Id != domain.Moon || first.PointC.Id != domain.Mars ||
+		first.PointD.Id != domain.CupidoUra {
+		t.Errorf("Expected Sun+Moon-Mars = Cupido, got %v", first)
+	}
+	if math.Abs(first.SensitivePoint-40.0) > 1e-8 || math.Abs(first.ActualOrb-1.0) > 1e-8 ||
+		math.Abs(first.Exactness-100.0/3.0) > 1e-8 {
+		t.Errorf("Expected sensitive point 40.0, orb 1.0 and exactness 33.3, got %v", first)
+	}
+}
+
+func TestPlanetaryPicturesBalance(t *testing.T) {
+	points := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 50.0},
+		{Id: domain.Mars, Position: 20.0},
+		{Id: domain.CupidoUra, Position: 41.0},
+	}
+	mc := MidpointsCalculation{}
+	result, err := mc.CalcPlanetaryPictures(points, domain.PictureBalance, domain.Dial360, 1.5)
+	if err != nil {
+		t.Fatalf("CalcPlanetaryPictures returned unexpected error %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 planetary picture, got %d: %v", len(result), result)
+	}
+	if result[0].PointC.Id != domain.Mars || result[0].PointD.Id != domain.CupidoUra || math.Abs(result[0].ActualOrb-1.0) > 1e-8 {
+		t.Errorf("Expected Sun+Moon = Mars+Cupido with orb 1.0, got %v", result[0])
+	}
+}
+
+func TestPlanetaryPicturesDial90SortedByExactness(t *testing.T) {
+	points := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 100.0},      // 10 in dial
+		{Id: domain.Moon, Position: 140.0},     // 50 in dial
+		{Id: domain.Mars, Position: 200.0},     // 20 in dial
+		{Id: domain.CupidoUra, Position: 311},  // 41 in dial
+		{Id: domain.HadesUra, Position: 220.5}, // 40.5 in dial
+	}
+	mc := MidpointsCalculation{}
+	result, err := mc.CalcPlanetaryPictures(points, domain.PictureSum, domain.Dial90, 1.5)
+	if err != nil {
+		t.Fatalf("CalcPlanetaryPictures returned unexpected error %v", err)
+	}
+	if len(result) == 0 || result[0].PointD.Id != domain.HadesUra || math.Abs(result[0].ActualOrb-0.5) > 1e-8 {
+		t.Fatalf("Expected Sun+Moon-Mars = Hades with orb 0.5 as first result, got %v", result)
+	}
+	for i := 1; i < len(result); i++ {
+		if result[i].Exactness > result[i-1].Exactness {
+			t.Errorf("Results not sorted by exactness at index %d", i)
+		}
+	}
+}
+
+func TestMidpointTreesOccupation(t *testing.T) {
+	points := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 10.0},
+		{Id: domain.Moon, Position: 50.0},     // Sun/Moon = 30.0
+		{Id: domain.Mars, Position: 31.0},     // direct
+		{Id: domain.Jupiter, Position: 120.5}, // square
+		{Id: domain.Saturn, Position: 75.5},   // octile
+	}
+	mc := MidpointsCalculation{}
+	result, err := mc.CalcMidpointTrees(points, domain.Dial90, 1.5)
+	if err != nil {
+		t.Fatalf("CalcMidpointTrees returned unexpected error %v", err)
+	}
+	if len(result) != len(points) {
+		t.Fatalf("Expected %d trees, got %d", len(points), len(result))
+	}
+	expected := map[domain.ChartPoint]domain.MidpointOccupation{
+		domain.Mars:    domain.OccupationDirect,
+		domain.Jupiter: domain.OccupationSquare,
+		domain.Saturn:  domain.OccupationOctile,
+	}
+	for _, tree := range result {
+		expOccupation, ok := expected[tree.FocusPoint.Id]
+		if !ok {
+			continue
+		}
+		found := false
+		for _, treeMp := range tree.Midpoints {
+			if treeMp.Midpoint.BaseMidpointPos1.Id == domain.Sun && treeMp.Midpoint.BaseMidpointPos2.Id == domain.Moon {
+				found = true
+				if treeMp.Occupation != expOccupation {
+					t.Errorf("Expected occupation %d for point %d, got %d", expOccupation, tree.FocusPoint.Id, treeMp.Occupation)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Expected Sun/Moon in tree for point %d", tree.FocusPoint.Id)
+		}
+		for i := 1; i < len(tree.Midpoints); i++ {
+			if tree.Midpoints[i].Midpoint.ActualOrb < tree.Midpoints[i-1].Midpoint.ActualOrb {
+				t.Errorf("Tree for point %d is not sorted by orb", tree.FocusPoint.Id)
+			}
+		}
+	}
+}
+
+func TestOccupationType(t *testing.T) {
+	if occupationType(200.0, 20.5) != domain.OccupationDirect {
+		t.Errorf("Expected direct occupation for opposition")
+	}
+	if occupationType(45.0, 112.5) != domain.OccupationSemiOctile {
+		t.Errorf("Expected semi-octile occupation for 67.5 degrees")
+	}
+	if occupationType(0.0, 348.75) != domain.OccupationHalfSemiOctile {
+		t.Errorf("Expected half semi-octile occupation for 11.25 degrees")
+	}
+}
+
+func TestDialSort(t *testing.T) {
+	points := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 100.0},
+		{Id: domain.Moon, Position: 20.0},
+	}
+	mc := MidpointsCalculation{}
+	result, err := mc.CalcDialSort(points, domain.Dial90)
+	if err != nil {
+		t.Fatalf("CalcDialSort returned unexpected error %v", err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(result))
+	}
+	if result[0].Point1.Id != domain.Sun || math.Abs(result[0].DialPos-10.0) > 1e-8 || result[0].IsMidpoint {
+		t.Errorf("Expected Sun at 10.0 as first item, got %v", result[0])
+	}
+	if result[1].Point1.Id != domain.Moon || math.Abs(result[1].DialPos-20.0) > 1e-8 {
+		t.Errorf("Expected Moon at 20.0 as second item, got %v", result[1])
+	}
+	if !result[2].IsMidpoint || math.Abs(result[2].DialPos-60.0) > 1e-8 {
+		t.Errorf("Expected midpoint at 60.0 as third item, got %v", result[2])
+	}
+}
+
+func TestCalcFramedMidpointsEquatorial(t *testing.T) {
+	points := []domain.PointPosResult{
+		{Point: domain.Sun, LonPos: 10.0, RaPos: 10.0},
+		{Point: domain.Moon, LonPos: 50.0, RaPos: 50.0},
+		{Point: domain.Mercury, LonPos: 100.0, RaPos: 30.5},
+	}
+	mc := MidpointsCalculation{}
+	result, err := mc.CalcFramedMidpoints(points, domain.CoordEquatorial, domain.Dial360, 1.0)
+	if err != nil {
+		t.Fatalf("CalcFramedMidpoints returned unexpected error %v", err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 framed midpoint, got %d", len(result))
+	}
+	if result[0].Frame != domain.CoordEquatorial || result[0].Midpoint.FocusPoint.Id != domain.Mercury {
+		t.Errorf("Expected Mercury at the midpoint in right ascension, got %v", result[0])
+	}
+	if math.Abs(result[0].Midpoint.ActualOrb-0.5) > 1e-8 {
+		t.Errorf("Expected orb 0.5, got %f", result[0].Midpoint.ActualOrb)
+	}
+}
+
+func TestCalcFramedMidpointsUnsupportedFrame(t *testing.T) {
+	points := []domain.PointPosResult{
+		{Point: domain.Sun}, {Point: domain.Moon}, {Point: domain.Mercury},
+	}
+	mc := MidpointsCalculation{}
+	if _, err := mc.CalcFramedMidpoints(points, domain.CoordinateSystem(9), domain.Dial360, 1.0); err == nil {
+		t.Errorf("Expected error for unsupported frame")
+	}
+}
@@ -0,0 +1,120 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"math"
+)
+
+// CreateOrbTable precomputes the orbs for all combinations of two configured points and an aspect, using the base
+// orb for aspects and the orb method from orbCfg. The zero value for the orb method uses the largest orb factor of
+// both points, multiplied by the orb factor of the aspect and the base orb.
+// Points that are not configured have an orb factor of zero.
+func (ac AspectsCalculation) CreateOrbTable(aspects []domain.Aspect,
+	cfgPoints []domain.ConfigPoint,
+	cfgAspects []domain.ConfigAspect,
+	orbCfg domain.ConfigOrbs) domain.OrbTable {
+	pointIndex := make(map[domain.ChartPoint]int, len(cfgPoints))
+	pointFactors := make([]float64, 0, len(cfgPoints)+1)
+	widened := make([]bool, 0, len(cfgPoints)+1)
+	for _, cfgPoint := range cfgPoints {
+		if index, found := pointIndex[cfgPoint.ActualPoint]; found {
+			pointFactors[index] = cfgPoint.OrbFactor
+			continue
+		}
+		pointIndex[cfgPoint.ActualPoint] = len(pointFactors)
+		pointFactors = append(pointFactors, cfgPoint.OrbFactor)
+		widened = append(widened, orbCfg.OrbMethod == domain.OrbMethodLuminaries && isLuminaryOrAngle(cfgPoint.ActualPoint))
+	}
+	pointFactors = append(pointFactors, 0.0) // points that are not configured
+	widened = append(widened, false)
+	distances := make([]float64, len(aspects))
+	aspectFactors := make([]float64, len(aspects))
+	aspectIndex := make(map[domain.Aspect]int, len(aspects))
+	for k, aspect := range aspects {
+		distances[k] = aspectDistance(aspect, cfgAspects)
+		aspectIndex[aspect] = k
+		for _, cfgAspect := range cfgAspects {
+			if aspect == cfgAspect.ActualAspect {
+				aspectFactors[k] = cfgAspect.OrbFactor
+			}
+		}
+	}
+	orbs := make([][][]float64, len(pointFactors))
+	for i := range pointFactors {
+		orbs[i] = make([][]float64, len(pointFactors))
+		for j := range pointFactors {
+			orbs[i][j] = make([]float64, len(aspects))
+			for k := range aspects {
+				orb := methodOrb(orbCfg.OrbMethod, pointFactors[i], pointFactors[j], aspectFactors[k], orbCfg.BaseOrbAspects)
+				if widened[i] || widened[j] {
+					orb *= orbCfg.OrbLuminaryFactor / 100.0
+				}
+				orbs[i][j][k] = orb
+			}
+		}
+	}
+	if orbCfg.OrbMethod == domain.OrbMethodExplicit {
+		for _, explicitOrb := range orbCfg.ExplicitOrbs {
+			i, found1 := pointIndex[explicitOrb.Point1]
+			j, found2 := pointIndex[explicitOrb.Point2]
+			k, found3 := aspectIndex[explicitOrb.ActualAspect]
+			if found1 && found2 && found3 {
+				orbs[i][j][k] = explicitOrb.Orb
+				orbs[j][i][k] = explicitOrb.Orb
+			}
+		}
+	}
+	return domain.OrbTable{
+		Aspects:    append([]domain.Aspect(nil), aspects...),
+		Distances:  distances,
+		PointIndex: pointIndex,
+		Orbs:       orbs,
+	}
+}
+
+// aspectDistance returns the distance for a built-in aspect, or the distance for a custom aspect as defined in
+// cfgAspects. Returns zero for a custom aspect that is not configured.
+func aspectDistance(aspect domain.Aspect, cfgAspects []domain.ConfigAspect) float64 {
+	if aspect < domain.FirstCustomAspect {
+		return domain.AllAspects()[aspect].Distance
+	}
+	for _, cfgAspect := range cfgAspects {
+		if cfgAspect.ActualAspect == aspect {
+			return cfgAspect.Distance
+		}
+	}
+	return 0.0
+}
+
+// methodOrb calculates the orb from the orb factors of two points and an aspect. The factors are percentages.
+// A moiety is half the orb of a point, the average of the moieties is half the sum of the moieties.
+func methodOrb(method domain.OrbMethod, factor1, factor2, aspectFactor, baseOrb float64) float64 {
+	switch method {
+	case domain.OrbMethodMoietiesAverage:
+		return (((factor1/2.0 + factor2/2.0) / 2.0 * aspectFactor) / 10000) * baseOrb
+	case domain.OrbMethodMoietiesSum:
+		return (((factor1/2.0 + factor2/2.0) * aspectFactor) / 10000) * baseOrb
+	default:
+		return ((math.Max(factor1, factor2) * aspectFactor) / 10000) * baseOrb
+	}
+}
+
+// isLuminaryOrAngle checks for the Sun, the Moon, the ascendant and the MC.
+func isLuminaryOrAngle(point domain.ChartPoint) bool {
+	return point == domain.Sun || point == domain.Moon || point == domain.Ascendant || point == domain.Mc
+}
+
+// orbTableIndex returns the index of a point in the orb table, or the index for points that are not configured.
+func orbTableIndex(table *domain.OrbTable, point domain.ChartPoint) int {
+	if index, found := table.PointIndex[point]; found {
+		return index
+	}
+	return len(table.Orbs) - 1
+}
@@ -0,0 +1,237 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/meta"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestCreateOrbTable(t *testing.T) {
+	aspects := []domain.Aspect{domain.Conjunction, domain.Sextile}
+	cfgPoints := []domain.ConfigPoint{
+		{ActualPoint: domain.Sun, OrbFactor: 100},
+		{ActualPoint: domain.Saturn, OrbFactor: 60},
+	}
+	cfgAspects := []domain.ConfigAspect{
+		{ActualAspect: domain.Conjunction, OrbFactor: 100},
+		{ActualAspect: domain.Sextile, OrbFactor: 60},
+	}
+	ac := AspectsCalculation{}
+	table := ac.CreateOrbTable(aspects, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	sun := table.PointIndex[domain.Sun]
+	saturn := table.PointIndex[domain.Saturn]
+	unknown := orbTableIndex(&table, domain.Moon)
+	tests := []struct {
+		i, j, k  int
+		expected float64
+	}{
+		{sun, saturn, 0, 10.0},
+		{saturn, saturn, 0, 6.0},
+		{saturn, saturn, 1, 3.6},
+		{unknown, saturn, 1, 3.6},
+		{unknown, unknown, 0, 0.0},
+	}
+	for _, tt := range tests {
+		if math.Abs(table.Orbs[tt.i][tt.j][tt.k]-tt.expected) > delta {
+			t.Errorf("CreateOrbTable expected orb %f for [%d][%d][%d], got %f", tt.expected, tt.i, tt.j, tt.k, table.Orbs[tt.i][tt.j][tt.k])
+		}
+	}
+	if math.Abs(table.Distances[1]-60.0) > delta {
+		t.Errorf("CreateOrbTable expected distance 60.0 for sextile, got %f", table.Distances[1])
+	}
+}
+
+func TestCreateOrbTableMethods(t *testing.T) {
+	aspects := []domain.Aspect{domain.Conjunction}
+	cfgPoints := []domain.ConfigPoint{
+		{ActualPoint: domain.Sun, OrbFactor: 100},
+		{ActualPoint: domain.Saturn, OrbFactor: 60},
+		{ActualPoint: domain.Mars, OrbFactor: 80},
+	}
+	cfgAspects := []domain.ConfigAspect{{ActualAspect: domain.Conjunction, OrbFactor: 100}}
+	explicitOrbs := []domain.ConfigExplicitOrb{
+		{Point1: domain.Saturn, Point2: domain.Sun, ActualAspect: domain.Conjunction, Orb: 2.5},
+	}
+	tests := []struct {
+		method   domain.OrbMethod
+		point1   domain.ChartPoint
+		point2   domain.ChartPoint
+		expected float64
+	}{
+		{domain.OrbMethodMaxFactor, domain.Sun, domain.Saturn, 10.0},
+		{domain.OrbMethodExplicit, domain.Sun, domain.Saturn, 2.5},
+		{domain.OrbMethodExplicit, domain.Mars, domain.Saturn, 8.0},
+		{domain.OrbMethodMoietiesAverage, domain.Sun, domain.Saturn, 4.0},
+		{domain.OrbMethodMoietiesSum, domain.Sun, domain.Saturn, 8.0},
+		{domain.OrbMethodLuminaries, domain.Sun, domain.Saturn, 12.5},
+		{domain.OrbMethodLuminaries, domain.Mars, domain.Saturn, 8.0},
+	}
+	ac := AspectsCalculation{}
+	for _, tt := range tests {
+		orbCfg := domain.ConfigOrbs{
+			BaseOrbAspects:    10.0,
+			OrbMethod:         tt.method,
+			OrbLuminaryFactor: 125.0,
+			ExplicitOrbs:      explicitOrbs,
+		}
+		table := ac.CreateOrbTable(aspects, cfgPoints, cfgAspects, orbCfg)
+		orb := table.Orbs[table.PointIndex[tt.point1]][table.PointIndex[tt.point2]][0]
+		if math.Abs(orb-tt.expected) > delta {
+			t.Errorf("CreateOrbTable with method %d expected orb %f for %d and %d, got %f", tt.method, tt.expected, tt.point1, tt.point2, orb)
+		}
+	}
+}
+
+func TestCalcAspectsWithOrbTableZeroOrb(t *testing.T) {
+	points := []domain.SinglePosition{
+		{Id: domain.Moon, Position: 100.0},
+		{Id: domain.Mars, Position: 100.0},
+	}
+	cfgPoints := []domain.ConfigPoint{
+		{ActualPoint: domain.Sun, OrbFactor: 100},
+		{ActualPoint: domain.Saturn, OrbFactor: 60},
+	}
+	cfgAspects := []domain.ConfigAspect{{ActualAspect: domain.Conjunction, OrbFactor: 100}}
+	ac := AspectsCalculation{}
+	table := ac.CreateOrbTable([]domain.Aspect{domain.Conjunction}, cfgPoints, cfgAspects, domain.ConfigOrbs{BaseOrbAspects: 10.0})
+	result, err := ac.CalcAspectsWithOrbTable(points, &table)
+	if err != nil {
+		t.Fatalf("CalcAspectsWithOrbTable returned unexpected error %v", err)
+	}
+	if len(result) != 0 {
+		t.Errorf("CalcAspectsWithOrbTable expected no aspects for a zero orb, got %v", result)
+	}
+}
+
+func TestOrbTableIdenticalResults(t *testing.T) {
+	cfg := meta.DefaultConfig()
+	aspects := make([]domain.Aspect, 0, len(cfg.Aspects))
+	for _, cfgAspect := range cfg.Aspects {
+		aspects = append(aspects, cfgAspect.ActualAspect)
+	}
+	ac := AspectsCalculation{}
+	table := ac.CreateOrbTable(aspects, cfg.Points, cfg.Aspects, cfg.Orbs)
+	rnd := rand.New(rand.NewSource(42))
+	for chart := 0; chart < 20; chart++ {
+		points := randomPositions(rnd, cfg.Points)
+		expected := referenceAspects(points, aspects, cfg.Points, cfg.Aspects, cfg.Orbs.BaseOrbAspects)
+		result, err := ac.CalcAspects(points, aspects, cfg.Points, cfg.Aspects, cfg.Orbs)
+		if err != nil {
+			t.Fatalf("CalcAspects returned unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("CalcAspects differs from reference for chart %d", chart)
+		}
+		result, err = ac.CalcAspectsWithOrbTable(points, &table)
+		if err != nil {
+			t.Fatalf("CalcAspectsWithOrbTable returned unexpected error %v", err)
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("CalcAspectsWithOrbTable differs from reference for chart %d", chart)
+		}
+	}
+}
+
+func BenchmarkAspectsReference(b *testing.B) {
+	cfg, aspects, charts := benchmarkInput()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		referenceAspects(charts[n%len(charts)], aspects, cfg.Points, cfg.Aspects, cfg.Orbs.BaseOrbAspects)
+	}
+}
+
+func BenchmarkCalcAspects(b *testing.B) {
+	cfg, aspects, charts := benchmarkInput()
+	ac := AspectsCalculation{}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _ = ac.CalcAspects(charts[n%len(charts)], aspects, cfg.Points, cfg.Aspects, cfg.Orbs)
+	}
+}
+
+func BenchmarkCalcAspectsWithOrbTable(b *testing.B) {
+	cfg, aspects, charts := benchmarkInput()
+	ac := AspectsCalculation{}
+	table := ac.CreateOrbTable(aspects, cfg.Points, cfg.Aspects, cfg.Orbs)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _ = ac.CalcAspectsWithOrbTable(charts[n%len(charts)], &table)
+	}
+}
+
+func benchmarkInput() (domain.Config, []domain.Aspect, [][]domain.SinglePosition) {
+	cfg := meta.DefaultConfig()
+	aspects := make([]domain.Aspect, 0, len(cfg.Aspects))
+	for _, cfgAspect := range cfg.Aspects {
+		aspects = append(aspects, cfgAspect.ActualAspect)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	charts := make([][]domain.SinglePosition, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		charts = append(charts, randomPositions(rnd, cfg.Points))
+	}
+	return cfg, aspects, charts
+}
+
+func randomPositions(rnd *rand.Rand, cfgPoints []domain.ConfigPoint) []domain.SinglePosition {
+	points := make([]domain.SinglePosition, 0, len(cfgPoints))
+	for _, cfgPoint := range cfgPoints {
+		points = append(points, domain.SinglePosition{Id: cfgPoint.ActualPoint, Position: rnd.Float64() * 360.0})
+	}
+	return points
+}
+
+// referenceAspects is the straightforward calculation of aspects, without an orb table. It scans the configuration
+// for each combination of points and aspects and is used to verify the results and the speed of the orb table.
+func referenceAspects(points []domain.SinglePosition,
+	aspects []domain.Aspect,
+	cfgPoints []domain.ConfigPoint,
+	cfgAspects []domain.ConfigAspect,
+	baseOrb float64) []domain.ActualAspect {
+	actualAspects := make([]domain.ActualAspect, 0)
+	for i := 0; i < len(points); i++ {
+		for j := i + 1; j < len(points); j++ {
+			distance1 := math.Abs(points[i].Position - points[j].Position)
+			distance2 := 360.0 - distance1
+			for _, aspect := range aspects {
+				var factor1, factor2, aspectFactor, aspectDistance float64
+				for _, cfgPoint := range cfgPoints {
+					if cfgPoint.ActualPoint == points[i].Id {
+						factor1 = cfgPoint.OrbFactor
+					}
+					if cfgPoint.ActualPoint == points[j].Id {
+						factor2 = cfgPoint.OrbFactor
+					}
+				}
+				for _, cfgAspect := range cfgAspects {
+					if aspect == cfgAspect.ActualAspect {
+						aspectFactor = cfgAspect.OrbFactor
+						aspectDistance = domain.AllAspects()[aspect].Distance
+					}
+				}
+				orb := ((math.Max(factor1, factor2) * aspectFactor) / 10000) * baseOrb
+				actDelta := math.Min(math.Abs(distance1-aspectDistance), math.Abs(distance2-aspectDistance))
+				if actDelta <= orb {
+					actualAspects = append(actualAspects, domain.ActualAspect{
+						Pos1:         points[i],
+						Pos2:         points[j],
+						ActualAspect: aspect,
+						ActualOrb:    actDelta,
+						Exactness:    100 - int((actDelta/orb)*100),
+					})
+				}
+			}
+		}
+	}
+	return actualAspects
+}
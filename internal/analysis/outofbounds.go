@@ -0,0 +1,84 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/se"
+	"math"
+)
+
+// OutOfBoundsCalculator checks declinations for out-of-bounds positions and calculates parallels that take
+// out-of-bounds positions into account.
+type OutOfBoundsCalculator interface {
+	CalcOutOfBounds(declinations []domain.SinglePosition, jdUt float64) ([]domain.OobPosition, error)
+	CalcOobParallels(declinations []domain.SinglePosition, jdUt float64, orb float64) ([]domain.MatchedParallel, error)
+}
+
+type OutOfBoundsCalculation struct {
+	epsCalc se.SwephEpsilonCalculator
+}
+
+func NewOutOfBoundsCalculation() OutOfBoundsCalculator {
+	ec := se.NewSwephEpsilonCalculation()
+	return OutOfBoundsCalculation{ec}
+}
+
+// CalcOutOfBounds checks each declination against the true obliquity for jdUt.
+func (obc OutOfBoundsCalculation) CalcOutOfBounds(declinations []domain.SinglePosition, jdUt float64) ([]domain.OobPosition, error) {
+	obliquity, err := obc.epsCalc.CalcEpsilon(jdUt, true)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.OobPosition, 0, len(declinations))
+	for _, decl := range declinations {
+		excess := math.Max(math.Abs(decl.Position)-obliquity, 0.0)
+		result = append(result, domain.OobPosition{
+			Pos:         decl,
+			OutOfBounds: excess > 0.0,
+			Excess:      excess,
+			Mirrored:    mirrorDeclination(decl.Position, obliquity),
+		})
+	}
+	return result, nil
+}
+
+// CalcOobParallels calculates parallels and contraparallels, using the mirrored declination for out-of-bounds
+// points, e.g. with an obliquity of 23.5 a point at 25.0 N is treated as a point at 22.0 N.
+// The results contain the original declinations, the orb is based on the mirrored declinations.
+func (obc OutOfBoundsCalculation) CalcOobParallels(declinations []domain.SinglePosition, jdUt float64, orb float64) ([]domain.MatchedParallel, error) {
+	obliquity, err := obc.epsCalc.CalcEpsilon(jdUt, true)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.MatchedParallel, 0)
+	for i := 0; i < len(declinations); i++ {
+		for j := i + 1; j < len(declinations); j++ {
+			mirrored1 := domain.SinglePosition{Id: declinations[i].Id, Position: mirrorDeclination(declinations[i].Position, obliquity)}
+			mirrored2 := domain.SinglePosition{Id: declinations[j].Id, Position: mirrorDeclination(declinations[j].Position, obliquity)}
+			if parallel, found := matchParallel(mirrored1, mirrored2, orb); found {
+				parallel.Pos1 = declinations[i]
+				parallel.Pos2 = declinations[j]
+				result = append(result, parallel)
+			}
+		}
+	}
+	return result, nil
+}
+
+// mirrorDeclination reflects an out-of-bounds declination at the boundary, declinations in bounds are not changed.
+func mirrorDeclination(declination, obliquity float64) float64 {
+	oobPart := math.Abs(declination) - obliquity
+	if oobPart <= 0.0 {
+		return declination
+	}
+	if declination > 0.0 {
+		return obliquity - oobPart
+	}
+	return oobPart - obliquity
+}
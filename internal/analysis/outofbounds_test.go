@@ -0,0 +1,80 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+func TestCalcOutOfBounds(t *testing.T) {
+	declinations := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 20.0},
+		{Id: domain.Moon, Position: 25.0},
+		{Id: domain.Mars, Position: -24.5},
+	}
+	expected := []struct {
+		oob      bool
+		excess   float64
+		mirrored float64
+	}{
+		{false, 0.0, 20.0},
+		{true, 1.5, 22.0},
+		{true, 1.0, -22.5},
+	}
+	obc := OutOfBoundsCalculation{FakeSeEpsilonCalculation{}} // obliquity 23.5
+	result, err := obc.CalcOutOfBounds(declinations, 2_451_545.0)
+	if err != nil {
+		t.Fatalf("CalcOutOfBounds returned unexpected error %v", err)
+	}
+	for i, exp := range expected {
+		if result[i].OutOfBounds != exp.oob || math.Abs(result[i].Excess-exp.excess) > delta ||
+			math.Abs(result[i].Mirrored-exp.mirrored) > delta {
+			t.Errorf("CalcOutOfBounds expected %v at index %d, got %v", exp, i, result[i])
+		}
+	}
+}
+
+func TestCalcOobParallels(t *testing.T) {
+	declinations := []domain.SinglePosition{
+		{Id: domain.Sun, Position: 22.2},
+		{Id: domain.Moon, Position: 25.0},  // mirrored 22.0
+		{Id: domain.Mars, Position: -24.9}, // mirrored -22.1
+	}
+	obc := OutOfBoundsCalculation{FakeSeEpsilonCalculation{}} // obliquity 23.5
+	result, err := obc.CalcOobParallels(declinations, 2_451_545.0, 0.5)
+	if err != nil {
+		t.Fatalf("CalcOobParallels returned unexpected error %v", err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("CalcOobParallels expected 3 results, got %v", result)
+	}
+	if result[0].Pos2.Position != 25.0 || !result[0].Parallel || math.Abs(result[0].Orb-0.2) > delta {
+		t.Errorf("CalcOobParallels expected parallel Sun - Moon with orb 0.2 and original declination, got %v", result[0])
+	}
+	if result[2].Parallel || math.Abs(result[2].Orb-0.1) > delta {
+		t.Errorf("CalcOobParallels expected contraparallel Moon - Mars with orb 0.1, got %v", result[2])
+	}
+}
+
+func TestMirrorDeclination(t *testing.T) {
+	tests := []struct {
+		decl, expected float64
+	}{
+		{10.0, 10.0},
+		{-23.5, -23.5},
+		{28.5, 18.5},
+		{-28.5, -18.5},
+	}
+	for _, tt := range tests {
+		if result := mirrorDeclination(tt.decl, 23.5); math.Abs(result-tt.expected) > delta {
+			t.Errorf("mirrorDeclination(%f) expected %f, got %f", tt.decl, tt.expected, result)
+		}
+	}
+}
@@ -15,6 +15,8 @@ import (
 // ParallelsCalculator calculates parallels and contra-parallels.
 type ParallelsCalculator interface {
 	CalcParallels(actPositions []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error)
+	CalcInterParallels(positionsA []domain.SinglePosition, positionsB []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error)
+	CalcLatitudeParallels(latitudes []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error)
 }
 
 type ParallelsCalculation struct{}
@@ -29,15 +31,44 @@ func (pc ParallelsCalculation) CalcParallels(actPositions []domain.SinglePositio
 
 	for i := 0; i < len(actPositions); i++ {
 		for j := i + 1; j < len(actPositions); j++ {
-			pos1 := actPositions[i].Position
-			pos2 := actPositions[j].Position
-			distance := math.Abs(math.Abs(pos1) - math.Abs(pos2))
-			if distance <= orb {
-				notContra := (pos1 >= 0.0 && pos2 >= 0.0) || (pos1 <= 0.0 && pos2 <= 0.0)
-				result = append(result, domain.MatchedParallel{Pos1: actPositions[i], Pos2: actPositions[j], Orb: distance, Parallel: notContra})
+			if parallel, found := matchParallel(actPositions[i], actPositions[j], orb); found {
+				result = append(result, parallel)
 			}
 		}
 	}
 
 	return result, nil
 }
+
+// CalcInterParallels calculates parallels and contraparallels between the positions of two charts.
+// Pos1 in the results refers to positionsA and Pos2 to positionsB.
+func (pc ParallelsCalculation) CalcInterParallels(positionsA []domain.SinglePosition, positionsB []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error) {
+	result := make([]domain.MatchedParallel, 0)
+	for _, posA := range positionsA {
+		for _, posB := range positionsB {
+			if parallel, found := matchParallel(posA, posB, orb); found {
+				result = append(result, parallel)
+			}
+		}
+	}
+	return result, nil
+}
+
+// CalcLatitudeParallels calculates parallels and contraparallels of celestial latitude. The same rules apply as for
+// parallels in declination: a parallel for equal latitudes at the same side of the ecliptic, a contraparallel for
+// equal latitudes at opposite sides.
+func (pc ParallelsCalculation) CalcLatitudeParallels(latitudes []domain.SinglePosition, orb float64) ([]domain.MatchedParallel, error) {
+	return pc.CalcParallels(latitudes, orb)
+}
+
+// matchParallel checks two declinations for a parallel or contraparallel.
+func matchParallel(point1, point2 domain.SinglePosition, orb float64) (domain.MatchedParallel, bool) {
+	pos1 := point1.Position
+	pos2 := point2.Position
+	distance := math.Abs(math.Abs(pos1) - math.Abs(pos2))
+	if distance <= orb {
+		notContra := (pos1 >= 0.0 && pos2 >= 0.0) || (pos1 <= 0.0 && pos2 <= 0.0)
+		return domain.MatchedParallel{Pos1: point1, Pos2: point2, Orb: distance, Parallel: notContra}, true
+	}
+	return domain.MatchedParallel{}, false
+}
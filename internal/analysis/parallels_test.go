@@ -58,3 +58,44 @@ func TestCalcParallelsHappyFlow(t *testing.T) {
 		}
 	}
 }
+
+func TestCalcInterParallels(t *testing.T) {
+	positionsA := []domain.SinglePosition{
+		{Id: 0, Position: 12.0},
+		{Id: 1, Position: 12.5},
+	}
+	positionsB := []domain.SinglePosition{
+		{Id: 0, Position: -12.2},
+		{Id: 4, Position: 3.0},
+	}
+	pc := ParallelsCalculation{}
+	result, err := pc.CalcInterParallels(positionsA, positionsB, 1.0)
+	if err != nil {
+		t.Fatalf("CalcInterParallels returned unexpected error %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("Expected 2 results, got %d: %v", len(result), result)
+	}
+	if result[0].Pos1.Position != 12.0 || result[0].Pos2.Position != -12.2 || result[0].Parallel {
+		t.Errorf("Expected contraparallel between 12.0 and -12.2, got %v", result[0])
+	}
+	if math.Abs(result[1].Orb-0.3) > 1e-8 {
+		t.Errorf("Expected orb 0.3, got %f", result[1].Orb)
+	}
+}
+
+func TestCalcLatitudeParallels(t *testing.T) {
+	latitudes := []domain.SinglePosition{
+		{Id: domain.Moon, Position: 4.8},
+		{Id: domain.Venus, Position: -5.1},
+		{Id: domain.Pluto, Position: 12.0},
+	}
+	pc := ParallelsCalculation{}
+	result, err := pc.CalcLatitudeParallels(latitudes, 0.5)
+	if err != nil {
+		t.Fatalf("CalcLatitudeParallels returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Parallel || math.Abs(result[0].Orb-0.3) > 0.00001 {
+		t.Errorf("CalcLatitudeParallels expected contraparallel Moon - Venus with orb 0.3, got %v", result)
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/calc"
+	"enigma-ar/internal/se"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// RelationChartCalculator calculates charts that combine two charts: composite charts and Davison charts.
+type RelationChartCalculator interface {
+	CalcComposite(requestA domain.FullChartRequest, requestB domain.FullChartRequest) (domain.FullChartResponse, error)
+	CalcDavison(requestA domain.FullChartRequest, requestB domain.FullChartRequest) (domain.FullChartResponse, error)
+}
+
+type RelationChartCalculation struct {
+	fcc     calc.FullChartCalculator
+	epsCalc se.SwephEpsilonCalculator
+}
+
+func NewRelationChartCalculation() RelationChartCalculator {
+	fcc := calc.NewFullChartCalculation()
+	ec := se.NewSwephEpsilonCalculation()
+	return RelationChartCalculation{fcc, ec}
+}
+
+// CalcComposite calculates both charts and returns a composite chart. All positions of points and mundane points
+// are the midpoints of the corresponding positions in both charts. Longitude, right ascension and azimuth use the
+// midpoint at the shortest arc, all other values, including speeds, are averaged.
+// PRE requestA and requestB use the same points and the same housesystem
+// POST no errors -> returns composite chart. Errors: returns empty response and error
+func (rcc RelationChartCalculation) CalcComposite(requestA domain.FullChartRequest, requestB domain.FullChartRequest) (domain.FullChartResponse, error) {
+	var response domain.FullChartResponse
+	chartA, err := rcc.fcc.CalcFullChart(requestA)
+	if err != nil {
+		return response, err
+	}
+	chartB, err := rcc.fcc.CalcFullChart(requestB)
+	if err != nil {
+		return response, err
+	}
+	if len(chartA.Cusps) != len(chartB.Cusps) {
+		return response, errors.New("composite requires the same number of cusps in both charts")
+	}
+	pointsB := make(map[domain.ChartPoint]domain.PointPosResult)
+	for _, point := range chartB.Points {
+		pointsB[point.Point] = point
+	}
+	points := make([]domain.PointPosResult, 0, len(chartA.Points))
+	for _, pointA := range chartA.Points {
+		pointB, found := pointsB[pointA.Point]
+		if !found {
+			return response, fmt.Errorf("point %d is not available in both charts", pointA.Point)
+		}
+		points = append(points, compositePoint(pointA, pointB))
+	}
+	var cusps []domain.HousePosResult
+	if chartA.Cusps != nil {
+		cusps = make([]domain.HousePosResult, 0, len(chartA.Cusps))
+		for i := range chartA.Cusps {
+			cusps = append(cusps, compositeMundane(chartA.Cusps[i], chartB.Cusps[i]))
+		}
+	}
+	response = domain.FullChartResponse{
+		Points:    points,
+		Mc:        compositeMundane(chartA.Mc, chartB.Mc),
+		Asc:       compositeMundane(chartA.Asc, chartB.Asc),
+		Vertex:    compositeMundane(chartA.Vertex, chartB.Vertex),
+		EastPoint: compositeMundane(chartA.EastPoint, chartB.EastPoint),
+		Cusps:     cusps,
+	}
+	return response, nil
+}
+
+// CalcDavison calculates a Davison chart: a regular chart for the midpoint in time and the midpoint in space.
+// The geographic longitude uses the midpoint at the shortest arc, latitude, elevation and atmospheric
+// conditions are averaged. The obliquity is recalculated for the new moment, all other settings are taken
+// from requestA.
+// POST no errors -> returns Davison chart. Errors: returns empty response and error
+func (rcc RelationChartCalculation) CalcDavison(requestA domain.FullChartRequest, requestB domain.FullChartRequest) (domain.FullChartResponse, error) {
+	request := requestA
+	request.Jd = (requestA.Jd + requestB.Jd) / 2.0
+	request.GeoLong = midpointGeoLong(requestA.GeoLong, requestB.GeoLong)
+	request.GeoLat = (requestA.GeoLat + requestB.GeoLat) / 2.0
+	request.Elevation = (requestA.Elevation + requestB.Elevation) / 2.0
+	request.AtmPressure = (requestA.AtmPressure + requestB.AtmPressure) / 2.0
+	request.AtmTemperature = (requestA.AtmTemperature + requestB.AtmTemperature) / 2.0
+	obliquity, err := rcc.epsCalc.CalcEpsilon(request.Jd, true)
+	if err != nil {
+		return domain.FullChartResponse{}, err
+	}
+	request.Obliquity = obliquity
+	return rcc.fcc.CalcFullChart(request)
+}
+
+func compositePoint(pointA, pointB domain.PointPosResult) domain.PointPosResult {
+	return domain.PointPosResult{
+		Point:     pointA.Point,
+		LonPos:    circularMidpoint(pointA.LonPos, pointB.LonPos),
+		LonSpeed:  (pointA.LonSpeed + pointB.LonSpeed) / 2.0,
+		LatPos:    (pointA.LatPos + pointB.LatPos) / 2.0,
+		LatSpeed:  (pointA.LatSpeed + pointB.LatSpeed) / 2.0,
+		RaPos:     circularMidpoint(pointA.RaPos, pointB.RaPos),
+		RaSpeed:   (pointA.RaSpeed + pointB.RaSpeed) / 2.0,
+		DeclPos:   (pointA.DeclPos + pointB.DeclPos) / 2.0,
+		DeclSpeed: (pointA.DeclSpeed + pointB.DeclSpeed) / 2.0,
+		RadvPos:   (pointA.RadvPos + pointB.RadvPos) / 2.0,
+		RadvSpeed: (pointA.RadvSpeed + pointB.RadvSpeed) / 2.0,
+		AzimPos:   circularMidpoint(pointA.AzimPos, pointB.AzimPos),
+		AltitPos:  (pointA.AltitPos + pointB.AltitPos) / 2.0,
+	}
+}
+
+func compositeMundane(posA, posB domain.HousePosResult) domain.HousePosResult {
+	return domain.HousePosResult{
+		LonPos:   circularMidpoint(posA.LonPos, posB.LonPos),
+		RaPos:    circularMidpoint(posA.RaPos, posB.RaPos),
+		DeclPos:  (posA.DeclPos + posB.DeclPos) / 2.0,
+		AzimPos:  circularMidpoint(posA.AzimPos, posB.AzimPos),
+		AltitPos: (posA.AltitPos + posB.AltitPos) / 2.0,
+	}
+}
+
+// circularMidpoint returns the midpoint at the shortest arc for two values in the range 0.0 ..< 360.0.
+func circularMidpoint(pos1, pos2 float64) float64 {
+	return constructEffectiveMidpoint(domain.SinglePosition{Position: pos1}, domain.SinglePosition{Position: pos2}, 360.0)
+}
+
+// midpointGeoLong returns the midpoint at the shortest arc for two geographic longitudes, east is positive.
+func midpointGeoLong(geoLong1, geoLong2 float64) float64 {
+	mp := circularMidpoint(math.Mod(geoLong1+360.0, 360.0), math.Mod(geoLong2+360.0, 360.0))
+	if mp > 180.0 {
+		mp -= 360.0
+	}
+	return mp
+}
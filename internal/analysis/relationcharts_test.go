@@ -0,0 +1,123 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package analysis
+
+import (
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+const delta = 0.00001
+
+func TestCalcComposite(t *testing.T) {
+	rcc := RelationChartCalculation{FakeFullChartCalculation{}, FakeSeEpsilonCalculation{}}
+	requestA := domain.FullChartRequest{Points: []domain.ChartPoint{domain.Sun, domain.Moon}, Jd: 350.0, GeoLat: 10.0}
+	requestB := domain.FullChartRequest{Points: []domain.ChartPoint{domain.Sun, domain.Moon}, Jd: 20.0, GeoLat: 20.0}
+	result, err := rcc.CalcComposite(requestA, requestB)
+	if err != nil {
+		t.Fatalf("CalcComposite returned unexpected error %v", err)
+	}
+	if len(result.Points) != 2 {
+		t.Fatalf("CalcComposite expected 2 points, got %d", len(result.Points))
+	}
+	if math.Abs(result.Points[0].LonPos-5.0) > delta {
+		t.Errorf("CalcComposite expected longitude 5.0 for the Sun, got %f", result.Points[0].LonPos)
+	}
+	if math.Abs(result.Points[1].LonPos-6.0) > delta || result.Points[1].Point != domain.Moon {
+		t.Errorf("CalcComposite expected longitude 6.0 for the Moon, got %v", result.Points[1])
+	}
+	if math.Abs(result.Points[0].LatPos-15.0) > delta {
+		t.Errorf("CalcComposite expected latitude 15.0, got %f", result.Points[0].LatPos)
+	}
+	if len(result.Cusps) != 13 || math.Abs(result.Cusps[1].LonPos-35.0) > delta {
+		t.Errorf("CalcComposite expected 13 cusps and cusp 1 at 35.0, got %v", result.Cusps)
+	}
+	if math.Abs(result.Mc.LonPos-275.0) > delta {
+		t.Errorf("CalcComposite expected MC at 275.0, got %f", result.Mc.LonPos)
+	}
+}
+
+func TestCalcCompositeDifferentPoints(t *testing.T) {
+	rcc := RelationChartCalculation{FakeFullChartCalculation{}, FakeSeEpsilonCalculation{}}
+	requestA := domain.FullChartRequest{Points: []domain.ChartPoint{domain.Sun, domain.Moon}, Jd: 350.0}
+	requestB := domain.FullChartRequest{Points: []domain.ChartPoint{domain.Sun, domain.Mars}, Jd: 20.0}
+	_, err := rcc.CalcComposite(requestA, requestB)
+	if err == nil {
+		t.Errorf("CalcComposite should have returned an error for different points")
+	}
+}
+
+func TestCalcDavison(t *testing.T) {
+	rcc := RelationChartCalculation{FakeFullChartCalculation{}, FakeSeEpsilonCalculation{}}
+	requestA := domain.FullChartRequest{Points: []domain.ChartPoint{domain.Sun}, Jd: 2000.0, GeoLong: 170.0,
+		GeoLat: 50.0, Elevation: 100.0, Obliquity: 22.0}
+	requestB := domain.FullChartRequest{Points: []domain.ChartPoint{domain.Sun}, Jd: 3000.0, GeoLong: -160.0,
+		GeoLat: -10.0, Elevation: 0.0, Obliquity: 22.0}
+	result, err := rcc.CalcDavison(requestA, requestB)
+	if err != nil {
+		t.Fatalf("CalcDavison returned unexpected error %v", err)
+	}
+	point := result.Points[0]
+	if math.Abs(point.LonPos-math.Mod(2500.0, 360.0)) > delta {
+		t.Errorf("CalcDavison expected jd 2500.0, got longitude %f", point.LonPos)
+	}
+	if math.Abs(point.RadvPos+175.0) > delta {
+		t.Errorf("CalcDavison expected geographic longitude -175.0, got %f", point.RadvPos)
+	}
+	if math.Abs(point.LatPos-20.0) > delta || math.Abs(point.AltitPos-50.0) > delta {
+		t.Errorf("CalcDavison expected latitude 20.0 and elevation 50.0, got %f and %f", point.LatPos, point.AltitPos)
+	}
+	if math.Abs(point.DeclPos-23.5) > delta {
+		t.Errorf("CalcDavison expected recalculated obliquity 23.5, got %f", point.DeclPos)
+	}
+}
+
+func TestMidpointGeoLong(t *testing.T) {
+	if math.Abs(midpointGeoLong(-10.0, 30.0)-10.0) > delta {
+		t.Errorf("midpointGeoLong expected 10.0, got %f", midpointGeoLong(-10.0, 30.0))
+	}
+	if math.Abs(midpointGeoLong(-100.0, -20.0)+60.0) > delta {
+		t.Errorf("midpointGeoLong expected -60.0, got %f", midpointGeoLong(-100.0, -20.0))
+	}
+}
+
+// FakeFullChartCalculation returns positions that are derived from the request: the longitude is the jd modulo 360,
+// the latitude is the geographic latitude, the radius vector is the geographic longitude, the declination is the
+// obliquity and the altitude is the elevation. The Moon is one degree further than the Sun.
+type FakeFullChartCalculation struct{}
+
+func (fake FakeFullChartCalculation) CalcFullChart(request domain.FullChartRequest) (domain.FullChartResponse, error) {
+	lon := math.Mod(request.Jd, 360.0)
+	points := make([]domain.PointPosResult, 0)
+	for _, point := range request.Points {
+		points = append(points, domain.PointPosResult{
+			Point:    point,
+			LonPos:   math.Mod(lon+float64(point), 360.0),
+			LatPos:   request.GeoLat,
+			RadvPos:  request.GeoLong,
+			DeclPos:  request.Obliquity,
+			AltitPos: request.Elevation,
+		})
+	}
+	cusps := make([]domain.HousePosResult, 0)
+	for i := 0; i < 13; i++ {
+		cusps = append(cusps, domain.HousePosResult{LonPos: math.Mod(lon+30.0*float64(i), 360.0)})
+	}
+	return domain.FullChartResponse{
+		Points: points,
+		Mc:     domain.HousePosResult{LonPos: math.Mod(lon+270.0, 360.0)},
+		Cusps:  cusps,
+	}, nil
+}
+
+type FakeSeEpsilonCalculation struct{}
+
+func (fake FakeSeEpsilonCalculation) CalcEpsilon(jdUt float64, trueEps bool) (float64, error) {
+	return 23.5, nil
+}
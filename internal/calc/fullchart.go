@@ -31,10 +31,13 @@ func (fcc FullChartCalculation) CalcFullChart(request domain.FullChartRequest) (
 
 	var response domain.FullChartResponse
 	houseRequest := domain.HousePosRequest{
-		HouseSys: request.HouseSys,
-		JdUt:     request.Jd,
-		GeoLong:  request.GeoLong,
-		GeoLat:   request.GeoLat,
+		HouseSys:       request.HouseSys,
+		JdUt:           request.Jd,
+		GeoLong:        request.GeoLong,
+		GeoLat:         request.GeoLat,
+		Elevation:      request.Elevation,
+		AtmPressure:    request.AtmPressure,
+		AtmTemperature: request.AtmTemperature,
 	}
 	housesResult, mundaneResult, mundaneErr := fcc.hpc.CalcHousePos(houseRequest)
 	if mundaneErr != nil {
@@ -42,16 +45,21 @@ func (fcc FullChartCalculation) CalcFullChart(request domain.FullChartRequest) (
 	}
 	armc := mundaneResult[1].RaPos
 	pointsRequest := domain.PointPositionsRequest{
-		Points:    request.Points,
-		JdUt:      request.Jd,
-		GeoLong:   request.GeoLong,
-		GeoLat:    request.GeoLat,
-		Armc:      armc,
-		Obliquity: request.Obliquity,
-		Coord:     request.CoordSys,
-		ObsPos:    request.ObsPos,
-		ProjType:  request.ProjType,
-		Ayanamsha: request.Ayanamsha,
+		Points:         request.Points,
+		JdUt:           request.Jd,
+		GeoLong:        request.GeoLong,
+		GeoLat:         request.GeoLat,
+		Elevation:      request.Elevation,
+		AtmPressure:    request.AtmPressure,
+		AtmTemperature: request.AtmTemperature,
+		Armc:           armc,
+		Obliquity:      request.Obliquity,
+		Coord:          request.CoordSys,
+		ObsPos:         request.ObsPos,
+		ObsCenter:      request.ObsCenter,
+		ProjType:       request.ProjType,
+		Ayanamsha:      request.Ayanamsha,
+		PosOptions:     request.PosOptions,
 	}
 	pointsResult, pointsErr := fcc.ppc.CalcPointPos(pointsRequest)
 	if pointsErr != nil {
@@ -41,12 +41,25 @@ type HousePosCalculator interface {
 	CalcHousePos(request domain.HousePosRequest) ([]domain.HousePosResult, []domain.HousePosResult, error)
 }
 
+// observerLocation combines the geographic location and the atmospheric conditions, as used for horizontal coordinates.
+// If planetocentric is true, centerId is the SE id of the body that is used as center.
+type observerLocation struct {
+	geoLong        float64
+	geoLat         float64
+	elevation      float64 // meters
+	pressure       float64 // hPa
+	temperature    float64 // Celsius
+	planetocentric bool
+	centerId       int
+}
+
 type PointPosCalculation struct {
 	sePointCalc   se.SwephPointPosCalculator
 	seHorPosCalc  se.SwephHorPosCalculator
 	elementsCalc  PointsElementsCalculator
 	seEpsilonCalc se.SwephEpsilonCalculator
 	sePrep        se.SwephPreparator
+	sePctrCalc    se.SwephPlanetocentricCalculator
 }
 
 func NewPointPosCalculation() PointPosCalculator {
@@ -55,7 +68,8 @@ func NewPointPosCalculation() PointPosCalculator {
 	elc := NewPointsElementsCalculation()
 	ec := se.NewSwephEpsilonCalculation()
 	prep := se.NewSwephPreparation()
-	return PointPosCalculation{ppc, hpc, elc, ec, prep}
+	pctr := se.NewSwephPlanetocentricCalculation()
+	return PointPosCalculation{ppc, hpc, elc, ec, prep, pctr}
 }
 
 // CalcPointPos calculates fully defined positions for one or more celestial points
@@ -68,13 +82,23 @@ func (calc PointPosCalculation) CalcPointPos(request domain.PointPositionsReques
 	jdUt := request.JdUt
 	geoLong := request.GeoLong
 	geoLat := request.GeoLat
-	altitude := 0.0 // altitude in meters
+	location := observerLocation{
+		geoLong:     geoLong,
+		geoLat:      geoLat,
+		elevation:   request.Elevation,
+		pressure:    request.AtmPressure,
+		temperature: request.AtmTemperature,
+	}
+	if request.ObsPos == domain.ObsPosPlanetocentric {
+		location.planetocentric = true
+		location.centerId = domain.AllChartPoints()[request.ObsCenter].CalcId
+	}
 
 	positions := make([]domain.PointPosResult, 0)
-	eclFlags := SeFlags(domain.CoordEcliptical, request.ObsPos, request.Ayanamsha)
-	equFlags := SeFlags(domain.CoordEquatorial, request.ObsPos, request.Ayanamsha)
+	eclFlags := SeFlags(domain.CoordEcliptical, request.ObsPos, request.Ayanamsha, request.PosOptions)
+	equFlags := SeFlags(domain.CoordEquatorial, request.ObsPos, request.Ayanamsha, request.PosOptions)
 	if request.ObsPos == domain.ObsPosTopocentric {
-		calc.sePrep.SetTopo(geoLong, geoLat, altitude)
+		calc.sePrep.SetTopo(geoLong, geoLat, request.Elevation)
 	}
 	var ayanOffset float64
 	var err error
@@ -95,7 +119,7 @@ func (calc PointPosCalculation) CalcPointPos(request domain.PointPositionsReques
 		calcCat = domain.AllChartPoints()[point].CalcCat
 		switch calcCat {
 		case domain.CalcSe:
-			position, err := calc.calcPointPosViaSe(calcId, point, jdUt, eclFlags, equFlags, geoLong, geoLat)
+			position, err := calc.calcPointPosViaSe(calcId, point, jdUt, eclFlags, equFlags, location)
 			if err != nil {
 				return nil, fmt.Errorf("calc point positions failed for %v", point)
 			}
@@ -133,7 +157,7 @@ func (calc PointPosCalculation) CalcPointPos(request domain.PointPositionsReques
 }
 
 func (calc PointPosCalculation) calcPointPosViaSe(index int, point domain.ChartPoint, jdUt float64,
-	eclFlags, equFlags int, geoLong, geoLat float64) (domain.PointPosResult, error) {
+	eclFlags, equFlags int, location observerLocation) (domain.PointPosResult, error) {
 
 	// TODO check if this initalisation is required
 	sep := string(filepath.Separator)
@@ -143,20 +167,20 @@ func (calc PointPosCalculation) calcPointPosViaSe(index int, point domain.ChartP
 
 	var position domain.PointPosResult
 	//posEcl, errEcl := calc.sePointCalc.CalcPointPos(jdUt, index, eclFlags)
-	posEcl, errEcl := calc.sePointCalc.CalcPointPos(jdUt, int(point), eclFlags)
+	posEcl, errEcl := calc.calcSePos(jdUt, int(point), eclFlags, location)
 	if errEcl != nil {
 		return position, errEcl
 	}
 	//posEqu, errEqu := calc.sePointCalc.CalcPointPos(jdUt, index, equFlags)
-	posEqu, errEqu := calc.sePointCalc.CalcPointPos(jdUt, int(point), equFlags)
+	posEqu, errEqu := calc.calcSePos(jdUt, int(point), equFlags, location)
 	if errEqu != nil {
 		return position, errEqu
 	}
-	height := 0.0
 	pointRa := posEqu[0]
 	pointDecl := posEqu[1]
 	horFlags := domain.SeflgEquatorial
-	posHor := calc.seHorPosCalc.CalcHorPos(jdUt, geoLong, geoLat, height, pointRa, pointDecl, horFlags)
+	posHor := calc.seHorPosCalc.CalcHorPos(jdUt, location.geoLong, location.geoLat, location.elevation,
+		location.pressure, location.temperature, pointRa, pointDecl, horFlags)
 	position = domain.PointPosResult{
 		Point:     point,
 		LonPos:    posEcl[0],
@@ -175,6 +199,14 @@ func (calc PointPosCalculation) calcPointPosViaSe(index int, point domain.ChartP
 	return position, nil
 }
 
+// calcSePos calculates the position via the SE, using the center body for planetocentric positions.
+func (calc PointPosCalculation) calcSePos(jdUt float64, body int, flags int, location observerLocation) ([6]float64, error) {
+	if location.planetocentric {
+		return calc.sePctrCalc.CalcPointPosPctr(jdUt, body, location.centerId, flags)
+	}
+	return calc.sePointCalc.CalcPointPos(jdUt, body, flags)
+}
+
 func (calc PointPosCalculation) calcElements(point domain.ChartPoint, jdUt float64,
 	ayanOffset float64, obsPos domain.ObserverPosition) (domain.PointPosResult, error) {
 	var position domain.PointPosResult
@@ -258,12 +290,11 @@ func (calc PointPosCalculation) calcCarteretHypPlanet(jdUt, startPoint, yearlySp
 func (calc PointPosCalculation) calcApogeeDuval(jdUt float64, eclFlags, equFlags int) (float64, error) {
 	flagsEcl := 2 + 256 // use SE + speed
 	factor1 := 12.37
-	geoLat := 0.0
-	geoLong := 0.0
+	location := observerLocation{} // horizontal positions are not used
 	indexSun := domain.AllChartPoints()[domain.Sun].CalcId
 	indexApogeeMean := domain.AllChartPoints()[domain.ApogeeMean].CalcId
-	longSun, err := calc.calcPointPosViaSe(indexSun, domain.Sun, jdUt, flagsEcl, equFlags, geoLong, geoLat)
-	longApogeeMean, err := calc.calcPointPosViaSe(indexApogeeMean, domain.ApogeeMean, jdUt, flagsEcl, equFlags, geoLong, geoLat)
+	longSun, err := calc.calcPointPosViaSe(indexSun, domain.Sun, jdUt, flagsEcl, equFlags, location)
+	longApogeeMean, err := calc.calcPointPosViaSe(indexApogeeMean, domain.ApogeeMean, jdUt, flagsEcl, equFlags, location)
 	fmt.Printf("indexApogeeMean %d, flagsEcl %d, jdUt %f\n", indexApogeeMean, flagsEcl, jdUt)
 
 	diff, err := ValueToRange(longSun.LonPos-longApogeeMean.LonPos, -180.0, 180.0)
@@ -285,11 +316,15 @@ func (calc PointPosCalculation) calcApogeeDuval(jdUt float64, eclFlags, equFlags
 
 type PointRangeCalculation struct {
 	sePointCalc se.SwephPointPosCalculator
+	sePrep      se.SwephPreparator
+	sePctrCalc  se.SwephPlanetocentricCalculator
 }
 
 func NewPointRangeCalculation() PointRangeCalculator {
 	ppc := se.NewSwephPointPosCalculation()
-	return PointRangeCalculation{ppc}
+	prep := se.NewSwephPreparation()
+	pctr := se.NewSwephPlanetocentricCalculation()
+	return PointRangeCalculation{ppc, prep, pctr}
 }
 
 func (prc PointRangeCalculation) CalcPointRange(request domain.PointRangeRequest) ([]domain.PointRangeResult, error) {
@@ -298,26 +333,36 @@ func (prc PointRangeCalculation) CalcPointRange(request domain.PointRangeRequest
 	allPoints := domain.AllChartPoints()
 	index := allPoints[reqPoint].CalcId
 
-	flags := SeFlags(request.Coord, request.ObsPos, request.Ayanamsha)
+	flags := SeFlags(request.Coord, request.ObsPos, request.Ayanamsha, request.PosOptions)
+	if request.Coord == domain.CoordEcliptical && request.Ayanamsha != domain.AyanNone {
+		prc.sePrep.SetSidereal(request.Ayanamsha)
+	}
 	// TODO handle topocentric
 	var rangePositions []domain.PointRangeResult
-	var resultIndex int
+	var resultIndex int // SE returns: main position, secondary position, distance, followed by the speeds in the same sequence
 	if request.Position {
 		if request.MainValue {
 			resultIndex = 0
 		} else {
-			resultIndex = 4
+			resultIndex = 1
 		}
 	} else {
 		if request.MainValue {
-			resultIndex = 1
+			resultIndex = 3
 		} else {
-			resultIndex = 5
+			resultIndex = 4
 		}
 	}
 	// TODO handle RADV/Distance
+	centerIndex := allPoints[request.ObsCenter].CalcId
 	for i := request.JdStart; i <= request.JdEnd; i += request.Interval {
-		sePos, err := prc.sePointCalc.CalcPointPos(i, index, flags)
+		var sePos [6]float64
+		var err error
+		if request.ObsPos == domain.ObsPosPlanetocentric {
+			sePos, err = prc.sePctrCalc.CalcPointPosPctr(i, index, centerIndex, flags)
+		} else {
+			sePos, err = prc.sePointCalc.CalcPointPos(i, index, flags)
+		}
 		if err != nil {
 			return rangePositions, err
 		}
@@ -365,12 +410,19 @@ func (hpc HousePosCalculation) CalcHousePos(request domain.HousePosRequest) ([]d
 	}
 	nrOfCuspValues := len(cuspsEcl)
 	lat := 0.0
+	location := observerLocation{
+		geoLong:     request.GeoLong,
+		geoLat:      request.GeoLat,
+		elevation:   request.Elevation,
+		pressure:    request.AtmPressure,
+		temperature: request.AtmTemperature,
+	}
 
 	for i := 1; i < nrOfCuspValues; i++ { // start with index 1, as the SE does the same
 		ra, decl := conversion.ChangeEclToEqu(cuspsEcl[i], lat, obliquity)
-		height := 0.0
 		horFlags := domain.SeflgEquatorial
-		posHor := hpc.seHorCalc.CalcHorPos(request.JdUt, request.GeoLong, request.GeoLat, height, ra, decl, horFlags)
+		posHor := hpc.seHorCalc.CalcHorPos(request.JdUt, location.geoLong, location.geoLat, location.elevation,
+			location.pressure, location.temperature, ra, decl, horFlags)
 		cuspPos[i] = domain.HousePosResult{
 			LonPos:   cuspsEcl[i],
 			RaPos:    ra,
@@ -380,19 +432,19 @@ func (hpc HousePosCalculation) CalcHousePos(request domain.HousePosRequest) ([]d
 		}
 	}
 
-	mcAscPos[0] = hpc.createHousePosResult(otherPointsEcl[0], lat, obliquity, request.JdUt, request.GeoLong, request.GeoLat) // Ascendant
-	mcAscPos[1] = hpc.createHousePosResult(otherPointsEcl[1], lat, obliquity, request.JdUt, request.GeoLong, request.GeoLat) // MC
-	mcAscPos[2] = hpc.createHousePosResult(otherPointsEcl[3], lat, obliquity, request.JdUt, request.GeoLong, request.GeoLat) // Vertex
-	mcAscPos[3] = hpc.createHousePosResult(otherPointsEcl[4], lat, obliquity, request.JdUt, request.GeoLong, request.GeoLat) // East point
+	mcAscPos[0] = hpc.createHousePosResult(otherPointsEcl[0], lat, obliquity, request.JdUt, location) // Ascendant
+	mcAscPos[1] = hpc.createHousePosResult(otherPointsEcl[1], lat, obliquity, request.JdUt, location) // MC
+	mcAscPos[2] = hpc.createHousePosResult(otherPointsEcl[3], lat, obliquity, request.JdUt, location) // Vertex
+	mcAscPos[3] = hpc.createHousePosResult(otherPointsEcl[4], lat, obliquity, request.JdUt, location) // East point
 
 	return cuspPos, mcAscPos, nil
 }
 
-func (hpc HousePosCalculation) createHousePosResult(position, lat, obliquity, jd, geoLong, geoLat float64) domain.HousePosResult {
+func (hpc HousePosCalculation) createHousePosResult(position, lat, obliquity, jd float64, location observerLocation) domain.HousePosResult {
 	ra, decl := conversion.ChangeEclToEqu(position, lat, obliquity)
-	height := 0.0
 	horFlags := domain.SeflgEquatorial
-	posHor := hpc.seHorCalc.CalcHorPos(jd, geoLong, geoLat, height, ra, decl, horFlags)
+	posHor := hpc.seHorCalc.CalcHorPos(jd, location.geoLong, location.geoLat, location.elevation,
+		location.pressure, location.temperature, ra, decl, horFlags)
 	return domain.HousePosResult{
 		LonPos:   position,
 		RaPos:    ra,
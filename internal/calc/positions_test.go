@@ -9,6 +9,7 @@ package calc
 
 import (
 	domain "enigma-ar/domain"
+	"enigma-ar/internal/se"
 	"math"
 	"testing"
 )
@@ -95,3 +96,156 @@ func TestCalcPointPosViaFormulaApogeeDuval(t *testing.T) {
 		t.Errorf("Error in calculation of Apogee (Duval), expected %f, got %f", expected, result[0].LonPos)
 	}
 }
+
+func TestCalcPointRangeDeclination(t *testing.T) {
+	prc := PointRangeCalculation{FakeSePointPosCalculation{}, FakeSePreparation{}, FakeSePlanetocentricCalculation{}}
+	request := domain.PointRangeRequest{
+		Point:     domain.Sun,
+		JdStart:   2451544.5,
+		JdEnd:     2451546.5,
+		Interval:  1.0,
+		Coord:     domain.CoordEquatorial,
+		MainValue: false,
+		Position:  true,
+		ObsPos:    domain.ObsPosGeocentric,
+		Ayanamsha: domain.AyanNone,
+	}
+	result, err := prc.CalcPointRange(request)
+	if err != nil {
+		t.Fatalf("CalcPointRange returned unexpected error %v", err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("CalcPointRange expected 3 results, got %d", len(result))
+	}
+	if math.Abs(result[1].Value-(-1.0)) > delta {
+		t.Errorf("CalcPointRange expected declination -1.0, got %f", result[1].Value)
+	}
+}
+
+func TestCalcPointRangeLongitudeSpeed(t *testing.T) {
+	prc := PointRangeCalculation{FakeSePointPosCalculation{}, FakeSePreparation{}, FakeSePlanetocentricCalculation{}}
+	request := domain.PointRangeRequest{
+		Point:     domain.Sun,
+		JdStart:   2451544.5,
+		JdEnd:     2451544.5,
+		Interval:  1.0,
+		Coord:     domain.CoordEcliptical,
+		MainValue: true,
+		Position:  false,
+		ObsPos:    domain.ObsPosGeocentric,
+		Ayanamsha: domain.AyanNone,
+	}
+	result, err := prc.CalcPointRange(request)
+	if err != nil {
+		t.Fatalf("CalcPointRange returned unexpected error %v", err)
+	}
+	if math.Abs(result[0].Value-3.0) > delta {
+		t.Errorf("CalcPointRange expected speed in longitude 3.0, got %f", result[0].Value)
+	}
+}
+
+func TestCalcPointRangePlanetocentric(t *testing.T) {
+	prc := PointRangeCalculation{FakeSePointPosCalculation{}, FakeSePreparation{}, FakeSePlanetocentricCalculation{}}
+	request := domain.PointRangeRequest{
+		Point:     domain.Sun,
+		JdStart:   2451544.5,
+		JdEnd:     2451544.5,
+		Interval:  1.0,
+		Coord:     domain.CoordEcliptical,
+		MainValue: true,
+		Position:  true,
+		ObsPos:    domain.ObsPosPlanetocentric,
+		ObsCenter: domain.Mars,
+		Ayanamsha: domain.AyanNone,
+	}
+	result, err := prc.CalcPointRange(request)
+	if err != nil {
+		t.Fatalf("CalcPointRange returned unexpected error %v", err)
+	}
+	// the fake adds the id of the center (Mars = 4) to the longitude
+	if math.Abs(result[0].Value-204.0) > delta {
+		t.Errorf("CalcPointRange expected planetocentric longitude 204.0, got %f", result[0].Value)
+	}
+}
+
+func TestCalcPointPosPlanetocentric(t *testing.T) {
+	c := PointPosCalculation{FakeSePointPosCalculation{}, FakeSeHorPosCalculation{}, NewPointsElementsCalculation(),
+		se.NewSwephEpsilonCalculation(), FakeSePreparation{}, FakeSePlanetocentricCalculation{}}
+	request := domain.PointPositionsRequest{
+		Points:    []domain.ChartPoint{domain.Sun},
+		JdUt:      2_451_545.0,
+		Coord:     domain.CoordEcliptical,
+		ObsPos:    domain.ObsPosPlanetocentric,
+		ObsCenter: domain.Jupiter,
+		ProjType:  domain.ProjType2D,
+		Ayanamsha: domain.AyanNone,
+	}
+	result, err := c.CalcPointPos(request)
+	if err != nil {
+		t.Fatalf("CalcPointPos returned unexpected error %v", err)
+	}
+	if math.Abs(result[0].LonPos-205.0) > delta {
+		t.Errorf("CalcPointPos expected planetocentric longitude 205.0, got %f", result[0].LonPos)
+	}
+}
+
+func TestCalcPointPosHorizontalUsesElevationAndAtmosphere(t *testing.T) {
+	c := PointPosCalculation{FakeSePointPosCalculation{}, FakeSeHorPosCalculation{}, NewPointsElementsCalculation(),
+		se.NewSwephEpsilonCalculation(), FakeSePreparation{}, FakeSePlanetocentricCalculation{}}
+	request := domain.PointPositionsRequest{
+		Points:         []domain.ChartPoint{domain.Moon},
+		JdUt:           2_451_545.0,
+		GeoLong:        6.9,
+		GeoLat:         52.2,
+		Elevation:      1200.0,
+		AtmPressure:    880.0,
+		AtmTemperature: 5.0,
+		Coord:          domain.CoordEcliptical,
+		ObsPos:         domain.ObsPosTopocentric,
+		ProjType:       domain.ProjType2D,
+		Ayanamsha:      domain.AyanNone,
+	}
+	result, err := c.CalcPointPos(request)
+	if err != nil {
+		t.Fatalf("CalcPointPos returned unexpected error %v", err)
+	}
+	if math.Abs(result[0].AzimPos-1200.0) > delta {
+		t.Errorf("CalcPointPos expected elevation 1200.0 to be used, got %f", result[0].AzimPos)
+	}
+	if math.Abs(result[0].AltitPos-885.0) > delta {
+		t.Errorf("CalcPointPos expected pressure 880.0 and temperature 5.0 to be used, got %f", result[0].AltitPos)
+	}
+}
+
+type FakeSePointPosCalculation struct{}
+
+func (fake FakeSePointPosCalculation) CalcPointPos(jdUt float64, body int, flags int) ([6]float64, error) {
+	return [6]float64{100.0, -1.0, 2.0, 3.0, 4.0, 5.0}, nil
+}
+
+type FakeSePreparation struct{}
+
+func (fake FakeSePreparation) SetEphePath(path string) {}
+
+func (fake FakeSePreparation) SetTopo(geoLong, geoLat, altitudeMtrs float64) {}
+
+func (fake FakeSePreparation) SetSidereal(ayanamsha domain.Ayanamsha) {}
+
+func (fake FakeSePreparation) AyanOffset(jdUt float64) (float64, error) {
+	return 0.0, nil
+}
+
+// FakeSeHorPosCalculation returns the elevation as azimuth and the sum of pressure and temperature as apparent altitude.
+type FakeSeHorPosCalculation struct{}
+
+func (fake FakeSeHorPosCalculation) CalcHorPos(jdUt float64, geoLong float64, geoLat float64, geoHeight float64,
+	atPress float64, atTemp float64, pointRa float64, pointDecl float64, flags int) [3]float64 {
+	return [3]float64{geoHeight, 0.0, atPress + atTemp}
+}
+
+// FakeSePlanetocentricCalculation returns a longitude of 200 plus the id of the center.
+type FakeSePlanetocentricCalculation struct{}
+
+func (fake FakeSePlanetocentricCalculation) CalcPointPosPctr(jdUt float64, body int, center int, flags int) ([6]float64, error) {
+	return [6]float64{200.0 + float64(center), -2.0, 3.0, 4.0, 5.0, 6.0}, nil
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // SeFlags calculates the total of all flags for the SE.
-func SeFlags(coord domain.CoordinateSystem, obsPos domain.ObserverPosition, ayan domain.Ayanamsha) int {
+func SeFlags(coord domain.CoordinateSystem, obsPos domain.ObserverPosition, ayan domain.Ayanamsha, options domain.PositionOptions) int {
 	flags := domain.SeflgSwieph + domain.SeflgSpeed // always use SE + speed
 	if coord == domain.CoordEquatorial {
 		flags += domain.SeflgEquatorial
@@ -24,9 +24,27 @@ func SeFlags(coord domain.CoordinateSystem, obsPos domain.ObserverPosition, ayan
 	if obsPos == domain.ObsPosHeliocentric {
 		flags += domain.SeflgHelioc
 	}
+	if obsPos == domain.ObsPosBarycentric {
+		flags += domain.SeflgBaryctr
+	}
 	if coord == domain.CoordEcliptical && !(ayan == domain.AyanNone) {
 		flags += domain.SeflgSidereal
 	}
+	if options.NoLightTime {
+		flags += domain.SeflgTruePos
+	}
+	if options.NoAberration {
+		flags += domain.SeflgNoAberr
+	}
+	if options.NoDeflection {
+		flags += domain.SeflgNoGDefl
+	}
+	if options.NoNutation {
+		flags += domain.SeflgNoNut
+	}
+	if options.J2000 {
+		flags += domain.SeflgJ2000
+	}
 	return flags
 }
 
@@ -14,7 +14,7 @@ import (
 )
 
 func TestSeFlagsEcliptical(t *testing.T) {
-	result := SeFlags(domain.CoordEcliptical, domain.ObsPosGeocentric, domain.AyanNone)
+	result := SeFlags(domain.CoordEcliptical, domain.ObsPosGeocentric, domain.AyanNone, domain.ApparentPositions())
 	expected := domain.SeflgSwieph + domain.SeflgSpeed
 	if result != expected {
 		t.Errorf("SeFlags() for ecliptical = %v, want %v", result, expected)
@@ -22,7 +22,7 @@ func TestSeFlagsEcliptical(t *testing.T) {
 }
 
 func TestSeFlagsEquatorial(t *testing.T) {
-	result := SeFlags(domain.CoordEquatorial, domain.ObsPosGeocentric, domain.AyanNone)
+	result := SeFlags(domain.CoordEquatorial, domain.ObsPosGeocentric, domain.AyanNone, domain.ApparentPositions())
 	expected := domain.SeflgSwieph + domain.SeflgSpeed + domain.SeflgEquatorial
 	if result != expected {
 		t.Errorf("SeFlags() for equatorial = %v, want %v", result, expected)
@@ -30,7 +30,7 @@ func TestSeFlagsEquatorial(t *testing.T) {
 }
 
 func TestSeFlagsTopcentric(t *testing.T) {
-	result := SeFlags(domain.CoordEcliptical, domain.ObsPosTopocentric, domain.AyanNone)
+	result := SeFlags(domain.CoordEcliptical, domain.ObsPosTopocentric, domain.AyanNone, domain.ApparentPositions())
 	expected := domain.SeflgSwieph + domain.SeflgSpeed + domain.SeflgTopoc
 	if result != expected {
 		t.Errorf("SeFlags() for topocentric = %v, want %v", result, expected)
@@ -38,15 +38,23 @@ func TestSeFlagsTopcentric(t *testing.T) {
 }
 
 func TestSeFlagsHeliocentric(t *testing.T) {
-	result := SeFlags(domain.CoordEcliptical, domain.ObsPosHeliocentric, domain.AyanNone)
+	result := SeFlags(domain.CoordEcliptical, domain.ObsPosHeliocentric, domain.AyanNone, domain.ApparentPositions())
 	expected := domain.SeflgSwieph + domain.SeflgSpeed + domain.SeflgHelioc
 	if result != expected {
 		t.Errorf("SeFlags() for heliocentric = %v, want %v", result, expected)
 	}
 }
 
+func TestSeFlagsBarycentric(t *testing.T) {
+	result := SeFlags(domain.CoordEcliptical, domain.ObsPosBarycentric, domain.AyanNone, domain.ApparentPositions())
+	expected := domain.SeflgSwieph + domain.SeflgSpeed + domain.SeflgBaryctr
+	if result != expected {
+		t.Errorf("SeFlags() for barycentric = %v, want %v", result, expected)
+	}
+}
+
 func TestSeFlagsSidereal(t *testing.T) {
-	result := SeFlags(domain.CoordEcliptical, domain.ObsPosGeocentric, domain.AyanDeLuce)
+	result := SeFlags(domain.CoordEcliptical, domain.ObsPosGeocentric, domain.AyanDeLuce, domain.ApparentPositions())
 	expected := domain.SeflgSwieph + domain.SeflgSpeed + domain.SeflgSidereal
 	if result != expected {
 		t.Errorf("SeFlags() for sidereal = %v, want %v", result, expected)
@@ -54,13 +62,33 @@ func TestSeFlagsSidereal(t *testing.T) {
 }
 
 func TestSeFlagsTopocEquatCombined(t *testing.T) {
-	result := SeFlags(domain.CoordEquatorial, domain.ObsPosTopocentric, domain.AyanNone)
+	result := SeFlags(domain.CoordEquatorial, domain.ObsPosTopocentric, domain.AyanNone, domain.ApparentPositions())
 	expected := domain.SeflgSwieph + domain.SeflgSpeed + domain.SeflgEquatorial + domain.SeflgTopoc
 	if result != expected {
 		t.Errorf("SeFlags() for equatorial/topocentric combined = %v, want %v", result, expected)
 	}
 }
 
+func TestSeFlagsTruePositions(t *testing.T) {
+	result := SeFlags(domain.CoordEcliptical, domain.ObsPosGeocentric, domain.AyanNone, domain.TruePositions())
+	expected := domain.SeflgSwieph + domain.SeflgSpeed + domain.SeflgTruePos + domain.SeflgNoAberr + domain.SeflgNoGDefl
+	if result != expected {
+		t.Errorf("SeFlags() for true positions = %v, want %v", result, expected)
+	}
+}
+
+func TestSeFlagsAstrometricJ2000NoNutation(t *testing.T) {
+	options := domain.AstrometricPositions()
+	options.J2000 = true
+	options.NoNutation = true
+	result := SeFlags(domain.CoordEquatorial, domain.ObsPosGeocentric, domain.AyanNone, options)
+	expected := domain.SeflgSwieph + domain.SeflgSpeed + domain.SeflgEquatorial + domain.SeflgNoAberr +
+		domain.SeflgNoGDefl + domain.SeflgJ2000 + domain.SeflgNoNut
+	if result != expected {
+		t.Errorf("SeFlags() for astrometric J2000 without nutation = %v, want %v", result, expected)
+	}
+}
+
 func TestValueToRangeHappyFlow(t *testing.T) {
 	testValue := 400.0
 	lowerLimit := 0.0
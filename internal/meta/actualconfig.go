@@ -11,6 +11,7 @@ import (
 	"enigma-ar/domain"
 	"fmt"
 	"image/color"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -45,6 +46,10 @@ func createConfig(deltas []string) (domain.Config, error) {
 		if err != nil {
 			return domain.Config{}, err
 		}
+		err = updateCustomAspects(newCfg, items[0], items[1])
+		if err != nil {
+			return domain.Config{}, err
+		}
 		err = updatePoints(newCfg, items[0], items[1])
 		if err != nil {
 			return domain.Config{}, err
@@ -94,6 +99,36 @@ func updateBasic(c *domain.Config, item, value string) error {
 			return err
 		}
 		c.Basic.Wheel = domain.WheelType(newWheelType)
+	case domain.CfgPosNoLightTime:
+		newNoLightTime, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		c.Basic.PosOptions.NoLightTime = newNoLightTime
+	case domain.CfgPosNoAberration:
+		newNoAberration, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		c.Basic.PosOptions.NoAberration = newNoAberration
+	case domain.CfgPosNoDeflection:
+		newNoDeflection, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		c.Basic.PosOptions.NoDeflection = newNoDeflection
+	case domain.CfgPosNoNutation:
+		newNoNutation, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		c.Basic.PosOptions.NoNutation = newNoNutation
+	case domain.CfgPosJ2000:
+		newJ2000, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		c.Basic.PosOptions.J2000 = newJ2000
 	}
 	return nil
 }
@@ -106,6 +141,24 @@ func updateOrbs(c *domain.Config, item, value string) error {
 			return err
 		}
 		c.Orbs.BaseOrbAspects = newBaseOrbAspects
+	case domain.CfgBaseOrbAspectsEqu:
+		newBaseOrbAspectsEquatorial, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		c.Orbs.BaseOrbAspectsEquatorial = newBaseOrbAspectsEquatorial
+	case domain.CfgBaseOrbAspectsHor:
+		newBaseOrbAspectsHorizontal, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		c.Orbs.BaseOrbAspectsHorizontal = newBaseOrbAspectsHorizontal
+	case domain.CfgBaseOrbAspectsMund:
+		newBaseOrbAspectsMundane, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		c.Orbs.BaseOrbAspectsMundane = newBaseOrbAspectsMundane
 	case domain.CfgBaseOrbMidpoints:
 		newBaseOrbMidpoints, err := strconv.ParseFloat(value, 64)
 		if err != nil {
@@ -118,6 +171,18 @@ func updateOrbs(c *domain.Config, item, value string) error {
 			return err
 		}
 		c.Orbs.OrbDeclMidpoints = newBaseOrbDeclMidpoints
+	case domain.CfgOrbRaMidpoints:
+		newOrbRaMidpoints, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		c.Orbs.OrbRaMidpoints = newOrbRaMidpoints
+	case domain.CfgOrbAzimMidpoints:
+		newOrbAzimuthMidpoints, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		c.Orbs.OrbAzimuthMidpoints = newOrbAzimuthMidpoints
 	case domain.CfgOrbParallels:
 		newBaseOrbParallels, err := strconv.ParseFloat(value, 64)
 		if err != nil {
@@ -148,10 +213,71 @@ func updateOrbs(c *domain.Config, item, value string) error {
 			return err
 		}
 		c.Orbs.OrbPrimDir = newBaseOrbPrimDir
+	case domain.CfgOrbAntiscia:
+		newOrbAntiscia, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		c.Orbs.OrbAntiscia = newOrbAntiscia
+	case domain.CfgOrbMethod:
+		newOrbMethod, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		c.Orbs.OrbMethod = domain.OrbMethod(newOrbMethod)
+	case domain.CfgOrbLuminaryFactor:
+		newOrbLuminaryFactor, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		c.Orbs.OrbLuminaryFactor = newOrbLuminaryFactor
+	case domain.CfgExplicitOrbs:
+		newExplicitOrbs, err := createExplicitOrbList(value)
+		if err != nil {
+			return err
+		}
+		c.Orbs.ExplicitOrbs = newExplicitOrbs
 	}
 	return nil
 }
 
+// createExplicitOrbList parses explicit orbs in the format point1:point2:aspect:orb, separated by a '|'.
+func createExplicitOrbList(value string) ([]domain.ConfigExplicitOrb, error) {
+	var explicitOrbs []domain.ConfigExplicitOrb
+	if value == "" {
+		return explicitOrbs, nil
+	}
+	for _, item := range strings.Split(value, "|") {
+		parts := strings.Split(item, ":")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("wrong nr of items for explicit orb: %v", item)
+		}
+		point1, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, err
+		}
+		point2, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, err
+		}
+		aspect, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return nil, err
+		}
+		orb, err := strconv.ParseFloat(parts[3], 64)
+		if err != nil {
+			return nil, err
+		}
+		explicitOrbs = append(explicitOrbs, domain.ConfigExplicitOrb{
+			Point1:       domain.ChartPoint(point1),
+			Point2:       domain.ChartPoint(point2),
+			ActualAspect: domain.Aspect(aspect),
+			Orb:          orb,
+		})
+	}
+	return explicitOrbs, nil
+}
+
 func updateAspects(c *domain.Config, item, value string) error {
 
 	if strings.HasPrefix(item, domain.CfgAspectX) {
@@ -172,6 +298,32 @@ func updateAspects(c *domain.Config, item, value string) error {
 	return nil
 }
 
+// updateCustomAspects replaces the custom aspect with the same number or adds a new custom aspect.
+func updateCustomAspects(c *domain.Config, item, value string) error {
+	if strings.HasPrefix(item, domain.CfgCustomAspectX) {
+		index := len(domain.CfgCustomAspectX)
+		aspectNr, err := strconv.Atoi(item[index:])
+		if err != nil {
+			return err
+		}
+		if domain.Aspect(aspectNr) < domain.FirstCustomAspect {
+			return fmt.Errorf("custom aspect %d must be >= %d", aspectNr, domain.FirstCustomAspect)
+		}
+		customAspect, err := constructCustomAspect(aspectNr, value)
+		if err != nil {
+			return err
+		}
+		for i, asp := range c.CustomAspects {
+			if asp.ActualAspect == customAspect.ActualAspect {
+				c.CustomAspects[i] = customAspect
+				return nil
+			}
+		}
+		c.CustomAspects = append(c.CustomAspects, customAspect)
+	}
+	return nil
+}
+
 func updatePoints(c *domain.Config, item, value string) error {
 	if strings.HasPrefix(item, domain.CfgPointX) {
 		index := len(domain.CfgPointX)
@@ -185,6 +337,23 @@ func updatePoints(c *domain.Config, item, value string) error {
 				if err != nil {
 					return err
 				}
+				c.Points[i].DistributionWeight = point.DistributionWeight
+			}
+		}
+	}
+	if strings.HasPrefix(item, domain.CfgPointWeightX) {
+		index := len(domain.CfgPointWeightX)
+		pointNr, err := strconv.Atoi(item[index:])
+		if err != nil {
+			return err
+		}
+		weight, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		for i, point := range c.Points {
+			if point.ActualPoint == domain.ChartPoint(pointNr) {
+				c.Points[i].DistributionWeight = weight
 			}
 		}
 	}
@@ -307,6 +476,60 @@ func constructAspect(aspectNr int, value string) (domain.ConfigAspect, error) {
 	return ca, nil
 }
 
+func constructCustomAspect(aspectNr int, value string) (domain.ConfigAspect, error) {
+	items := strings.Split(value, "|")
+	if len(items) != 7 {
+		return domain.ConfigAspect{}, fmt.Errorf("wrong nr of items for custom aspect")
+	}
+	values := make([]string, len(items))
+	for i, item := range items {
+		parts := strings.SplitN(item, ":", 2)
+		if len(parts) != 2 {
+			return domain.ConfigAspect{}, fmt.Errorf("wrong format for item %v of custom aspect", item)
+		}
+		values[i] = parts[1]
+	}
+	name, err := url.QueryUnescape(values[0])
+	if err != nil {
+		return domain.ConfigAspect{}, err
+	}
+	distance, err := strconv.ParseFloat(values[1], 64)
+	if err != nil {
+		return domain.ConfigAspect{}, err
+	}
+	used, err := strconv.ParseBool(values[2])
+	if err != nil {
+		return domain.ConfigAspect{}, err
+	}
+	show, err := strconv.ParseBool(values[3])
+	if err != nil {
+		return domain.ConfigAspect{}, err
+	}
+	ofact, err := strconv.ParseFloat(values[4], 64)
+	if err != nil {
+		return domain.ConfigAspect{}, err
+	}
+	runeInt, err := strconv.Atoi(values[5])
+	if err != nil {
+		return domain.ConfigAspect{}, err
+	}
+	col, err := rgbToNRGBA(values[6])
+	if err != nil {
+		return domain.ConfigAspect{}, err
+	}
+	ca := domain.ConfigAspect{
+		ActualAspect: domain.Aspect(aspectNr),
+		IsUsed:       used,
+		ShowInChart:  show,
+		OrbFactor:    ofact,
+		Glyph:        rune(runeInt),
+		Color:        col,
+		Name:         name,
+		Distance:     distance,
+	}
+	return ca, nil
+}
+
 func constructPoint(pointNr int, value string) (domain.ConfigPoint, error) {
 	items := strings.Split(value, "|")
 	if len(items) != 4 {
@@ -34,6 +34,23 @@ func TestActualConfigBase(t *testing.T) {
 	}
 }
 
+func TestActualConfigPosOptions(t *testing.T) {
+	deltas := []string{
+		domain.CfgPosNoAberration + "=true",
+		domain.CfgPosNoDeflection + "=true",
+		domain.CfgPosJ2000 + "=true",
+	}
+	actCfg, err := ActualConfig(deltas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := domain.AstrometricPositions()
+	expected.J2000 = true
+	if actCfg.Basic.PosOptions != expected {
+		t.Errorf("expected: %v, got: %v", expected, actCfg.Basic.PosOptions)
+	}
+}
+
 func TestActualConfigOrbs(t *testing.T) {
 	deltas := []string{
 		domain.CfgBaseOrbAspects + "=5",
@@ -51,6 +68,93 @@ func TestActualConfigOrbs(t *testing.T) {
 	}
 }
 
+func TestActualConfigOrbAntiscia(t *testing.T) {
+	deltas := []string{
+		domain.CfgOrbAntiscia + "=1.5",
+	}
+	actCfg, err := ActualConfig(deltas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(actCfg.Orbs.OrbAntiscia-1.5) > 1e-8 {
+		t.Errorf("expected: %v, got: %v", 1.5, actCfg.Orbs.OrbAntiscia)
+	}
+}
+
+func TestActualConfigAspectFrameOrbs(t *testing.T) {
+	deltas := []string{
+		domain.CfgBaseOrbAspectsEqu + "=7.0",
+		domain.CfgBaseOrbAspectsHor + "=6.0",
+		domain.CfgBaseOrbAspectsMund + "=5.0",
+	}
+	actCfg, err := ActualConfig(deltas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(actCfg.Orbs.BaseOrbAspectsEquatorial-7.0) > 1e-8 {
+		t.Errorf("expected: %v, got: %v", 7.0, actCfg.Orbs.BaseOrbAspectsEquatorial)
+	}
+	if math.Abs(actCfg.Orbs.BaseOrbAspectsHorizontal-6.0) > 1e-8 {
+		t.Errorf("expected: %v, got: %v", 6.0, actCfg.Orbs.BaseOrbAspectsHorizontal)
+	}
+	if math.Abs(actCfg.Orbs.BaseOrbAspectsMundane-5.0) > 1e-8 {
+		t.Errorf("expected: %v, got: %v", 5.0, actCfg.Orbs.BaseOrbAspectsMundane)
+	}
+}
+
+func TestActualConfigOrbMethod(t *testing.T) {
+	deltas := []string{
+		domain.CfgOrbMethod + "=1",
+		domain.CfgOrbLuminaryFactor + "=150.0",
+		domain.CfgExplicitOrbs + "=0:1:0:12.500000|0:4:3:3.000000",
+	}
+	actCfg, err := ActualConfig(deltas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actCfg.Orbs.OrbMethod != domain.OrbMethodExplicit {
+		t.Errorf("expected: %v, got: %v", domain.OrbMethodExplicit, actCfg.Orbs.OrbMethod)
+	}
+	if math.Abs(actCfg.Orbs.OrbLuminaryFactor-150.0) > 1e-8 {
+		t.Errorf("expected: %v, got: %v", 150.0, actCfg.Orbs.OrbLuminaryFactor)
+	}
+	if len(actCfg.Orbs.ExplicitOrbs) != 2 {
+		t.Fatalf("expected 2 explicit orbs, got: %v", len(actCfg.Orbs.ExplicitOrbs))
+	}
+	second := actCfg.Orbs.ExplicitOrbs[1]
+	if second.Point1 != domain.Sun || second.Point2 != domain.ChartPoint(4) || second.ActualAspect != domain.Square ||
+		math.Abs(second.Orb-3.0) > 1e-8 {
+		t.Errorf("wrong explicit orb: %v", second)
+	}
+}
+
+func TestActualConfigExplicitOrbsError(t *testing.T) {
+	deltas := []string{
+		domain.CfgExplicitOrbs + "=0:1:12.500000",
+	}
+	_, err := ActualConfig(deltas)
+	if err == nil {
+		t.Errorf("expected error for explicit orb with missing items")
+	}
+}
+
+func TestActualConfigMidpointOrbs(t *testing.T) {
+	deltas := []string{
+		domain.CfgOrbRaMidpoints + "=1.2",
+		domain.CfgOrbAzimMidpoints + "=0.8",
+	}
+	actCfg, err := ActualConfig(deltas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(actCfg.Orbs.OrbRaMidpoints-1.2) > 1e-8 {
+		t.Errorf("expected: %v, got: %v", 1.2, actCfg.Orbs.OrbRaMidpoints)
+	}
+	if math.Abs(actCfg.Orbs.OrbAzimuthMidpoints-0.8) > 1e-8 {
+		t.Errorf("expected: %v, got: %v", 0.8, actCfg.Orbs.OrbAzimuthMidpoints)
+	}
+}
+
 func TestActualConfigAspects(t *testing.T) {
 	deltas := []string{
 		domain.CfgAspectX + "0=use:true|show:true|factor:66.000000|glyph:59152|color:{255 255 0 255}",
@@ -98,6 +202,58 @@ func TestActualConfigAspects(t *testing.T) {
 
 }
 
+func TestActualConfigCustomAspects(t *testing.T) {
+	deltas := []string{
+		domain.CfgCustomAspectX + "1000=name:Tredecile+%7C+13|distance:27.692308|use:true|show:false|factor:5.000000|glyph:65|color:{128 0 128 255}",
+	}
+	actCfg, err := ActualConfig(deltas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actCfg.CustomAspects) != 1 {
+		t.Fatalf("expected 1 custom aspect, got: %v", len(actCfg.CustomAspects))
+	}
+	custom := actCfg.CustomAspects[0]
+	if custom.ActualAspect != domain.FirstCustomAspect || custom.Name != "Tredecile | 13" || !custom.IsUsed || custom.ShowInChart {
+		t.Errorf("wrong custom aspect: %v", custom)
+	}
+	if math.Abs(custom.Distance-27.692308) > 1e-8 || math.Abs(custom.OrbFactor-5.0) > 1e-8 {
+		t.Errorf("wrong distance or orb factor: %v", custom)
+	}
+	if custom.Glyph != 'A' || custom.Color.R != 128 || custom.Color.B != 128 {
+		t.Errorf("wrong glyph or color: %v", custom)
+	}
+}
+
+func TestActualConfigCustomAspectNumberTooSmall(t *testing.T) {
+	deltas := []string{
+		domain.CfgCustomAspectX + "3=name:x|distance:27.692308|use:true|show:false|factor:5.000000|glyph:65|color:{128 0 128 255}",
+	}
+	_, err := ActualConfig(deltas)
+	if err == nil {
+		t.Errorf("expected error for custom aspect with number below FirstCustomAspect")
+	}
+}
+
+func TestActualConfigPointWeight(t *testing.T) {
+	deltas := []string{
+		domain.CfgPointWeightX + "1=3.5",
+		domain.CfgPointX + "1=use:false|show:true|factor:1.000000|glyph:57864",
+	}
+	actCfg, err := ActualConfig(deltas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, act := range actCfg.Points {
+		if act.ActualPoint == domain.Moon && math.Abs(act.DistributionWeight-3.5) > 1e-8 {
+			t.Errorf("expected: %v, got: %v", 3.5, act.DistributionWeight)
+		}
+		if act.ActualPoint == domain.Mercury && math.Abs(act.DistributionWeight-1.0) > 1e-8 {
+			t.Errorf("expected: %v, got: %v", 1.0, act.DistributionWeight)
+		}
+	}
+}
+
 func TestActualConfigPoints(t *testing.T) {
 	deltas := []string{
 		domain.CfgPointX + "0=use:true|show:false|factor:5.500000|glyph:57863",
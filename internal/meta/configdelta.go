@@ -11,6 +11,7 @@ import (
 	"enigma-ar/domain"
 	"fmt"
 	"math"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
@@ -31,6 +32,7 @@ func ConfigDelta(newConfig domain.Config) ([]CfgDelta, error) {
 		return nil, err
 	}
 	allDeltas = append(allDeltas, newDeltas...)
+	allDeltas = append(allDeltas, compareCustomAspects(newConfig.CustomAspects, defaultConfig.CustomAspects)...)
 	newDeltas, err = comparePoints(newConfig.Points, defaultConfig.Points)
 	if err != nil {
 		return nil, err
@@ -72,6 +74,42 @@ func compareBasics(newCfgBasic, defaultCfgBasic domain.ConfigBasic) []CfgDelta {
 			newValue: strconv.Itoa(int(newCfgBasic.Wheel)),
 		})
 	}
+	newDeltas = append(newDeltas, comparePosOptions(newCfgBasic.PosOptions, defaultCfgBasic.PosOptions)...)
+	return newDeltas
+}
+
+func comparePosOptions(newOptions, defaultOptions domain.PositionOptions) []CfgDelta {
+	var newDeltas []CfgDelta
+	if newOptions.NoLightTime != defaultOptions.NoLightTime {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgPosNoLightTime,
+			newValue: strconv.FormatBool(newOptions.NoLightTime),
+		})
+	}
+	if newOptions.NoAberration != defaultOptions.NoAberration {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgPosNoAberration,
+			newValue: strconv.FormatBool(newOptions.NoAberration),
+		})
+	}
+	if newOptions.NoDeflection != defaultOptions.NoDeflection {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgPosNoDeflection,
+			newValue: strconv.FormatBool(newOptions.NoDeflection),
+		})
+	}
+	if newOptions.NoNutation != defaultOptions.NoNutation {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgPosNoNutation,
+			newValue: strconv.FormatBool(newOptions.NoNutation),
+		})
+	}
+	if newOptions.J2000 != defaultOptions.J2000 {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgPosJ2000,
+			newValue: strconv.FormatBool(newOptions.J2000),
+		})
+	}
 	return newDeltas
 }
 
@@ -83,12 +121,42 @@ func compareOrbs(newCfgOrb, defaultCfgOrb domain.ConfigOrbs) []CfgDelta {
 			newValue: fmt.Sprintf("%f", newCfgOrb.BaseOrbAspects),
 		})
 	}
+	if math.Abs(newCfgOrb.BaseOrbAspectsEquatorial-defaultCfgOrb.BaseOrbAspectsEquatorial) > 1e-8 {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgBaseOrbAspectsEqu,
+			newValue: fmt.Sprintf("%f", newCfgOrb.BaseOrbAspectsEquatorial),
+		})
+	}
+	if math.Abs(newCfgOrb.BaseOrbAspectsHorizontal-defaultCfgOrb.BaseOrbAspectsHorizontal) > 1e-8 {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgBaseOrbAspectsHor,
+			newValue: fmt.Sprintf("%f", newCfgOrb.BaseOrbAspectsHorizontal),
+		})
+	}
+	if math.Abs(newCfgOrb.BaseOrbAspectsMundane-defaultCfgOrb.BaseOrbAspectsMundane) > 1e-8 {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgBaseOrbAspectsMund,
+			newValue: fmt.Sprintf("%f", newCfgOrb.BaseOrbAspectsMundane),
+		})
+	}
 	if math.Abs(newCfgOrb.OrbDeclMidpoints-defaultCfgOrb.OrbDeclMidpoints) > 1e-8 {
 		newDeltas = append(newDeltas, CfgDelta{
 			cfgItem:  domain.CfgOrbDeclMidpoints,
 			newValue: fmt.Sprintf("%f", newCfgOrb.OrbDeclMidpoints),
 		})
 	}
+	if math.Abs(newCfgOrb.OrbRaMidpoints-defaultCfgOrb.OrbRaMidpoints) > 1e-8 {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgOrbRaMidpoints,
+			newValue: fmt.Sprintf("%f", newCfgOrb.OrbRaMidpoints),
+		})
+	}
+	if math.Abs(newCfgOrb.OrbAzimuthMidpoints-defaultCfgOrb.OrbAzimuthMidpoints) > 1e-8 {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgOrbAzimMidpoints,
+			newValue: fmt.Sprintf("%f", newCfgOrb.OrbAzimuthMidpoints),
+		})
+	}
 	if math.Abs(newCfgOrb.OrbParallels-defaultCfgOrb.OrbParallels) > 1e-8 {
 		newDeltas = append(newDeltas, CfgDelta{
 			cfgItem:  domain.CfgOrbParallels,
@@ -125,9 +193,43 @@ func compareOrbs(newCfgOrb, defaultCfgOrb domain.ConfigOrbs) []CfgDelta {
 			newValue: fmt.Sprintf("%f", newCfgOrb.OrbSymDir),
 		})
 	}
+	if math.Abs(newCfgOrb.OrbAntiscia-defaultCfgOrb.OrbAntiscia) > 1e-8 {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgOrbAntiscia,
+			newValue: fmt.Sprintf("%f", newCfgOrb.OrbAntiscia),
+		})
+	}
+	if newCfgOrb.OrbMethod != defaultCfgOrb.OrbMethod {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgOrbMethod,
+			newValue: strconv.Itoa(int(newCfgOrb.OrbMethod)),
+		})
+	}
+	if math.Abs(newCfgOrb.OrbLuminaryFactor-defaultCfgOrb.OrbLuminaryFactor) > 1e-8 {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgOrbLuminaryFactor,
+			newValue: fmt.Sprintf("%f", newCfgOrb.OrbLuminaryFactor),
+		})
+	}
+	if !reflect.DeepEqual(newCfgOrb.ExplicitOrbs, defaultCfgOrb.ExplicitOrbs) {
+		newDeltas = append(newDeltas, CfgDelta{
+			cfgItem:  domain.CfgExplicitOrbs,
+			newValue: createDetailsForExplicitOrbs(newCfgOrb.ExplicitOrbs),
+		})
+	}
 	return newDeltas
 }
 
+// createDetailsForExplicitOrbs returns the explicit orbs as point1:point2:aspect:orb, separated by a '|'.
+func createDetailsForExplicitOrbs(explicitOrbs []domain.ConfigExplicitOrb) string {
+	orbsAsStrings := make([]string, len(explicitOrbs))
+	for i, explicitOrb := range explicitOrbs {
+		orbsAsStrings[i] = fmt.Sprintf("%d:%d:%d:%f", explicitOrb.Point1, explicitOrb.Point2,
+			explicitOrb.ActualAspect, explicitOrb.Orb)
+	}
+	return strings.Join(orbsAsStrings, "|")
+}
+
 func compareAspects(newCfgAsp, defaultCfgAsp []domain.ConfigAspect) ([]CfgDelta, error) {
 	var newDeltas []CfgDelta
 	if len(newCfgAsp) != len(defaultCfgAsp) {
@@ -150,6 +252,30 @@ func compareAspects(newCfgAsp, defaultCfgAsp []domain.ConfigAspect) ([]CfgDelta,
 	return newDeltas, nil
 }
 
+// compareCustomAspects returns a delta for each custom aspect that is new or differs from the default.
+// The name is escaped, so it can contain any character. The distance is not rounded, it defines the exact aspect.
+func compareCustomAspects(newCfgAsp, defaultCfgAsp []domain.ConfigAspect) []CfgDelta {
+	var newDeltas []CfgDelta
+	for _, newAsp := range newCfgAsp {
+		found := false
+		for _, defAsp := range defaultCfgAsp {
+			if newAsp == defAsp {
+				found = true
+			}
+		}
+		if !found {
+			details := fmt.Sprintf("name:%s|distance:%s|use:%t|show:%t|factor:%f|glyph:%v|color:%v",
+				url.QueryEscape(newAsp.Name), strconv.FormatFloat(newAsp.Distance, 'f', -1, 64), newAsp.IsUsed, newAsp.ShowInChart, newAsp.OrbFactor,
+				newAsp.Glyph, newAsp.Color)
+			newDeltas = append(newDeltas, CfgDelta{
+				cfgItem:  domain.CfgCustomAspectX + strconv.Itoa(int(newAsp.ActualAspect)),
+				newValue: details,
+			})
+		}
+	}
+	return newDeltas
+}
+
 func comparePoints(newCfgPoints, defaultCfgPoints []domain.ConfigPoint) ([]CfgDelta, error) {
 	var newDeltas []CfgDelta
 	if len(newCfgPoints) != len(defaultCfgPoints) {
@@ -167,6 +293,12 @@ func comparePoints(newCfgPoints, defaultCfgPoints []domain.ConfigPoint) ([]CfgDe
 				newValue: details,
 			})
 		}
+		if math.Abs(newPoint.DistributionWeight-defPoint.DistributionWeight) > 1e-8 {
+			newDeltas = append(newDeltas, CfgDelta{
+				cfgItem:  domain.CfgPointWeightX + strconv.Itoa(int(defPoint.ActualPoint)),
+				newValue: fmt.Sprintf("%f", newPoint.DistributionWeight),
+			})
+		}
 	}
 	return newDeltas, nil
 }
@@ -92,6 +92,32 @@ func TestConfigDeltaAllBasics(t *testing.T) {
 	}
 }
 
+func TestConfigDeltaPosOptions(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	newConfig := defaultConfig
+	newConfig.Basic.PosOptions = domain.TruePositions()
+	expected := []CfgDelta{
+		{cfgItem: domain.CfgPosNoAberration, newValue: "true"},
+		{cfgItem: domain.CfgPosNoDeflection, newValue: "true"},
+		{cfgItem: domain.CfgPosNoLightTime, newValue: "true"},
+	}
+	result, err := ConfigDelta(newConfig)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("expected %d results, got %d", len(expected), len(result))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].cfgItem < result[j].cfgItem
+	})
+	for i := 0; i < len(expected); i++ {
+		if expected[i] != result[i] {
+			t.Errorf("expected: %v, got: %v", expected[i], result[i])
+		}
+	}
+}
+
 func TestConfigDeltaOrbs(t *testing.T) {
 	defaultConfig := DefaultConfig()
 	newConfig := defaultConfig
@@ -108,6 +134,109 @@ func TestConfigDeltaOrbs(t *testing.T) {
 	}
 }
 
+func TestConfigDeltaOrbAntiscia(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	newConfig := defaultConfig
+	newConfig.Orbs.OrbAntiscia = 2.0
+	result, err := ConfigDelta(newConfig)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 delta, got: %v", len(result))
+	}
+	if result[0].cfgItem != domain.CfgOrbAntiscia {
+		t.Errorf("expected: %v, got: %v", domain.CfgOrbAntiscia, result[0].cfgItem)
+	}
+	if result[0].newValue != "2.000000" {
+		t.Errorf("expected: %v, got: %v", "2.000000", result[0].newValue)
+	}
+}
+
+func TestConfigDeltaAspectFrameOrbs(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	newConfig := defaultConfig
+	newConfig.Orbs.BaseOrbAspectsMundane = 3.0
+	result, err := ConfigDelta(newConfig)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 delta, got: %v", len(result))
+	}
+	if result[0].cfgItem != domain.CfgBaseOrbAspectsMund {
+		t.Errorf("expected: %v, got: %v", domain.CfgBaseOrbAspectsMund, result[0].cfgItem)
+	}
+	if result[0].newValue != "3.000000" {
+		t.Errorf("expected: %v, got: %v", "3.000000", result[0].newValue)
+	}
+}
+
+func TestConfigDeltaExplicitOrbs(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	newConfig := defaultConfig
+	newConfig.Orbs.ExplicitOrbs = []domain.ConfigExplicitOrb{
+		{Point1: domain.Sun, Point2: domain.Moon, ActualAspect: domain.Conjunction, Orb: 12.5},
+	}
+	result, err := ConfigDelta(newConfig)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 delta, got: %v", len(result))
+	}
+	if result[0].cfgItem != domain.CfgExplicitOrbs {
+		t.Errorf("expected: %v, got: %v", domain.CfgExplicitOrbs, result[0].cfgItem)
+	}
+	if result[0].newValue != "0:1:0:12.500000" {
+		t.Errorf("expected: %v, got: %v", "0:1:0:12.500000", result[0].newValue)
+	}
+}
+
+func TestConfigDeltaCustomAspects(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	newConfig := defaultConfig
+	newConfig.CustomAspects = []domain.ConfigAspect{
+		{ActualAspect: domain.FirstCustomAspect + 1, IsUsed: true, ShowInChart: true, OrbFactor: 5.0, Glyph: 'B',
+			Color: color.NRGBA{R: 0, G: 128, B: 0, A: 255}, Name: "Quattuordecile = 14", Distance: 360.0 / 14.0},
+	}
+	result, err := ConfigDelta(newConfig)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 delta, got: %v", len(result))
+	}
+	delta := result[0].cfgItem + "=" + result[0].newValue
+	actCfg, err := ActualConfig([]string{delta})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(actCfg.CustomAspects) != 1 || actCfg.CustomAspects[0].Name != "Quattuordecile = 14" ||
+		actCfg.CustomAspects[0].Distance != 360.0/14.0 {
+		t.Errorf("custom aspect not restored from delta %v, got: %v", delta, actCfg.CustomAspects)
+	}
+}
+
+func TestConfigDeltaMidpointOrbs(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	newConfig := defaultConfig
+	newConfig.Orbs.OrbAzimuthMidpoints = 0.5
+	result, err := ConfigDelta(newConfig)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 delta, got: %v", len(result))
+	}
+	if result[0].cfgItem != domain.CfgOrbAzimMidpoints {
+		t.Errorf("expected: %v, got: %v", domain.CfgOrbAzimMidpoints, result[0].cfgItem)
+	}
+	if result[0].newValue != "0.500000" {
+		t.Errorf("expected: %v, got: %v", "0.500000", result[0].newValue)
+	}
+}
+
 func TestConfigDeltaAspects(t *testing.T) {
 	defaultConfig := DefaultConfig()
 	newConfig := defaultConfig
@@ -168,6 +297,26 @@ func TestConfigDeltaPoints(t *testing.T) {
 	}
 }
 
+func TestConfigDeltaPointWeight(t *testing.T) {
+	defaultConfig := DefaultConfig()
+	newConfig := defaultConfig
+	newConfig.Points[0].DistributionWeight = 3.0
+	result, err := ConfigDelta(newConfig)
+	if err != nil {
+		t.Error(err)
+	}
+	if len(result) != 1 {
+		t.Fatalf("expected 1 delta, got: %v", len(result))
+	}
+	expCfgItem := domain.CfgPointWeightX + "0"
+	if result[0].cfgItem != expCfgItem {
+		t.Errorf("expected: %v, got: %v", expCfgItem, result[0].cfgItem)
+	}
+	if result[0].newValue != "3.000000" {
+		t.Errorf("expected: %v, got: %v", "3.000000", result[0].newValue)
+	}
+}
+
 func TestConfigDeltaProgBase(t *testing.T) {
 	defaultConfig := DefaultConfig()
 	newConfig := defaultConfig
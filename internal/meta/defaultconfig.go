@@ -31,24 +31,33 @@ func DefaultConfig() domain.Config {
 
 func createBasic() domain.ConfigBasic {
 	return domain.ConfigBasic{
-		Houses:   domain.HousesPlacidus,
-		Ayan:     domain.AyanNone,
-		ObsPos:   domain.ObsPosGeocentric,
-		ProjType: domain.ProjType2D,
-		Wheel:    domain.WheelTypeSignsEqual,
+		Houses:     domain.HousesPlacidus,
+		Ayan:       domain.AyanNone,
+		ObsPos:     domain.ObsPosGeocentric,
+		ProjType:   domain.ProjType2D,
+		Wheel:      domain.WheelTypeSignsEqual,
+		PosOptions: domain.ApparentPositions(),
 	}
 }
 
 func createOrbs() domain.ConfigOrbs {
 	return domain.ConfigOrbs{
-		BaseOrbAspects:   10,
-		BaseOrbMidpoints: 1.6,
-		OrbDeclMidpoints: 0.5,
-		OrbParallels:     1.0,
-		OrbTransits:      1.0,
-		OrbSecDir:        1.0,
-		OrbSymDir:        1.0,
-		OrbPrimDir:       1.0,
+		BaseOrbAspects:           10,
+		BaseOrbAspectsEquatorial: 10,
+		BaseOrbAspectsHorizontal: 8,
+		BaseOrbAspectsMundane:    8,
+		BaseOrbMidpoints:         1.6,
+		OrbDeclMidpoints:         0.5,
+		OrbRaMidpoints:           1.6,
+		OrbAzimuthMidpoints:      1.0,
+		OrbParallels:             1.0,
+		OrbTransits:              1.0,
+		OrbSecDir:                1.0,
+		OrbSymDir:                1.0,
+		OrbPrimDir:               1.0,
+		OrbAntiscia:              1.0,
+		OrbMethod:                domain.OrbMethodMaxFactor,
+		OrbLuminaryFactor:        125.0,
 	}
 }
 
@@ -176,14 +185,23 @@ func createPoints() []domain.ConfigPoint {
 
 func createSpecPoint(actPoint domain.ChartPoint, isUsed, show bool, orbF float64, glyph rune) domain.ConfigPoint {
 	return domain.ConfigPoint{
-		ActualPoint: actPoint,
-		IsUsed:      isUsed,
-		ShowInChart: show,
-		OrbFactor:   orbF,
-		Glyph:       glyph,
+		ActualPoint:        actPoint,
+		IsUsed:             isUsed,
+		ShowInChart:        show,
+		OrbFactor:          orbF,
+		Glyph:              glyph,
+		DistributionWeight: distributionWeight(actPoint),
 	}
 }
 
+// distributionWeight returns the default weight for the chart distribution, the luminaries and the ascendant count double.
+func distributionWeight(actPoint domain.ChartPoint) float64 {
+	if actPoint == domain.Sun || actPoint == domain.Moon || actPoint == domain.Ascendant {
+		return 2.0
+	}
+	return 1.0
+}
+
 func createProg() domain.ConfigProg {
 	return domain.ConfigProg{
 		TransitPoints: []domain.ChartPoint{
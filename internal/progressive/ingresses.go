@@ -0,0 +1,288 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package progressive
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/calc"
+	"enigma-ar/internal/se"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	SignSize         = 30.0
+	PrecisionJd      = 0.000001 // approx. 0.1 second
+	MaxRefineActions = 100
+)
+
+// IngressCalculator finds the exact moments that a point enters a sign or a house, or enters or leaves
+// the out-of-bounds area.
+type IngressCalculator interface {
+	CalcSignIngresses(request domain.IngressRequest) ([]domain.SignIngress, error)
+	CalcHouseIngresses(request domain.IngressRequest, cusps []float64) ([]domain.HouseIngress, error)
+	CalcOobEvents(request domain.IngressRequest) ([]domain.OobEvent, error)
+}
+
+type IngressCalculation struct {
+	rangeCalc calc.PointRangeCalculator
+	epsCalc   se.SwephEpsilonCalculator
+}
+
+func NewIngressCalculation() IngressCalculator {
+	prc := calc.NewPointRangeCalculation()
+	ec := se.NewSwephEpsilonCalculation()
+	return IngressCalculation{prc, ec}
+}
+
+// crossing is a passage of a boundary, boundaryIndex refers to the sequence of the boundaries as used in the search.
+type crossing struct {
+	jd            float64
+	boundaryIndex int
+	retrograde    bool
+}
+
+// CalcSignIngresses finds all sign ingresses for the given point and period.
+// PRE request.Interval is small enough to prevent that a point passes more than one sign during the interval
+// POST no errors -> returns ingresses, sorted by jd. Errors: returns nil and error
+func (ic IngressCalculation) CalcSignIngresses(request domain.IngressRequest) ([]domain.SignIngress, error) {
+	boundaries := make([]float64, 0, 12)
+	for _, sign := range domain.AllSigns() {
+		boundaries = append(boundaries, float64(sign.Key)*SignSize)
+	}
+	crossings, err := ic.findLongitudeCrossings(request, boundaries)
+	if err != nil {
+		return nil, err
+	}
+	ingresses := make([]domain.SignIngress, 0, len(crossings))
+	for _, cr := range crossings {
+		signIndex := cr.boundaryIndex
+		if cr.retrograde { // point moves back into the previous sign
+			signIndex = (signIndex + len(boundaries) - 1) % len(boundaries)
+		}
+		ingresses = append(ingresses, domain.SignIngress{
+			Point:      request.Point,
+			Jd:         cr.jd,
+			Sign:       domain.Sign(signIndex),
+			Retrograde: cr.retrograde,
+		})
+	}
+	return ingresses, nil
+}
+
+// CalcHouseIngresses finds all house ingresses for the given point and period. The cusps are the longitudes of the
+// cusps, starting with the cusp of the first house, they are typically the cusps of a radix.
+// PRE request.Interval is small enough to prevent that a point passes more than one cusp during the interval
+// POST no errors -> returns ingresses, sorted by jd. Errors: returns nil and error
+func (ic IngressCalculation) CalcHouseIngresses(request domain.IngressRequest, cusps []float64) ([]domain.HouseIngress, error) {
+	crossings, err := ic.findLongitudeCrossings(request, cusps)
+	if err != nil {
+		return nil, err
+	}
+	ingresses := make([]domain.HouseIngress, 0, len(crossings))
+	for _, cr := range crossings {
+		houseIndex := cr.boundaryIndex
+		if cr.retrograde { // point moves back into the previous house
+			houseIndex = (houseIndex + len(cusps) - 1) % len(cusps)
+		}
+		ingresses = append(ingresses, domain.HouseIngress{
+			Point:      request.Point,
+			Jd:         cr.jd,
+			House:      houseIndex + 1,
+			Retrograde: cr.retrograde,
+		})
+	}
+	return ingresses, nil
+}
+
+// CalcOobEvents finds all moments that the point enters or leaves the out-of-bounds area. A point is out-of-bounds
+// if its declination, north or south, exceeds the true obliquity.
+// POST no errors -> returns events, sorted by jd. Errors: returns nil and error
+func (ic IngressCalculation) CalcOobEvents(request domain.IngressRequest) ([]domain.OobEvent, error) {
+	events := make([]domain.OobEvent, 0)
+	samples, err := ic.samples(request, domain.CoordEquatorial, false)
+	if err != nil {
+		return nil, err
+	}
+	oobDistance := func(jd float64) (float64, error) {
+		decl, err := ic.valueAt(request, domain.CoordEquatorial, false, jd)
+		if err != nil {
+			return 0.0, err
+		}
+		eps, err := ic.epsCalc.CalcEpsilon(jd, true)
+		if err != nil {
+			return 0.0, err
+		}
+		return math.Abs(decl) - eps, nil
+	}
+	sampleDistance := func(sample domain.PointRangeResult) (float64, error) {
+		eps, err := ic.epsCalc.CalcEpsilon(sample.Jd, true)
+		if err != nil {
+			return 0.0, err
+		}
+		return math.Abs(sample.Value) - eps, nil
+	}
+	prevDistance, err := sampleDistance(samples[0])
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i < len(samples); i++ {
+		distance, err := sampleDistance(samples[i])
+		if err != nil {
+			return nil, err
+		}
+		if (prevDistance <= 0.0) != (distance <= 0.0) {
+			jd, err := refine(oobDistance, samples[i-1].Jd, samples[i].Jd)
+			if err != nil {
+				return nil, err
+			}
+			decl, err := ic.valueAt(request, domain.CoordEquatorial, false, jd)
+			if err != nil {
+				return nil, err
+			}
+			eps, err := ic.epsCalc.CalcEpsilon(jd, true)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, domain.OobEvent{
+				Point:     request.Point,
+				Jd:        jd,
+				Entry:     distance > 0.0,
+				North:     decl >= 0.0,
+				Obliquity: eps,
+			})
+		}
+		prevDistance = distance
+	}
+	return events, nil
+}
+
+// findLongitudeCrossings samples the longitude and finds all passages of the boundaries.
+func (ic IngressCalculation) findLongitudeCrossings(request domain.IngressRequest, boundaries []float64) ([]crossing, error) {
+	crossings := make([]crossing, 0)
+	samples, err := ic.samples(request, domain.CoordEcliptical, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i < len(samples); i++ {
+		startLon := samples[i-1].Value
+		step := arcDifference(samples[i].Value, startLon)
+		if step == 0.0 {
+			continue
+		}
+		for bIndex, boundary := range boundaries {
+			dist := arcDifference(boundary, startLon)
+			passed := (step > 0.0 && dist > 0.0 && dist <= step) || (step < 0.0 && dist < 0.0 && dist >= step)
+			if !passed {
+				continue
+			}
+			distanceToBoundary := func(jd float64) (float64, error) {
+				lon, err := ic.valueAt(request, domain.CoordEcliptical, true, jd)
+				if err != nil {
+					return 0.0, err
+				}
+				return arcDifference(lon, boundary), nil
+			}
+			jd, err := refine(distanceToBoundary, samples[i-1].Jd, samples[i].Jd)
+			if err != nil {
+				return nil, err
+			}
+			crossings = append(crossings, crossing{jd: jd, boundaryIndex: bIndex, retrograde: step < 0.0})
+		}
+	}
+	sort.SliceStable(crossings, func(i, j int) bool {
+		return crossings[i].jd < crossings[j].jd
+	})
+	return crossings, nil
+}
+
+// samples retrieves the positions for the whole period, the end of the period is always included.
+func (ic IngressCalculation) samples(request domain.IngressRequest, coord domain.CoordinateSystem, mainValue bool) ([]domain.PointRangeResult, error) {
+	samples, err := ic.rangeCalc.CalcPointRange(ic.rangeRequest(request, coord, mainValue, request.JdStart, request.JdEnd))
+	if err != nil {
+		return nil, err
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no positions found for point %d", request.Point)
+	}
+	lastJd := samples[len(samples)-1].Jd
+	if request.JdEnd-lastJd > PrecisionJd {
+		value, err := ic.valueAt(request, coord, mainValue, request.JdEnd)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, domain.PointRangeResult{Jd: request.JdEnd, Value: value})
+	}
+	return samples, nil
+}
+
+// valueAt retrieves a single position by using a range that consists of one jd.
+func (ic IngressCalculation) valueAt(request domain.IngressRequest, coord domain.CoordinateSystem, mainValue bool, jd float64) (float64, error) {
+	result, err := ic.rangeCalc.CalcPointRange(ic.rangeRequest(request, coord, mainValue, jd, jd))
+	if err != nil {
+		return 0.0, err
+	}
+	if len(result) != 1 {
+		return 0.0, fmt.Errorf("expected one position for jd %f, found %d", jd, len(result))
+	}
+	return result[0].Value, nil
+}
+
+func (ic IngressCalculation) rangeRequest(request domain.IngressRequest, coord domain.CoordinateSystem, mainValue bool,
+	jdStart, jdEnd float64) domain.PointRangeRequest {
+	ayanamsha := request.Ayanamsha
+	if coord != domain.CoordEcliptical {
+		ayanamsha = domain.AyanNone
+	}
+	return domain.PointRangeRequest{
+		Point:     request.Point,
+		JdStart:   jdStart,
+		JdEnd:     jdEnd,
+		Interval:  request.Interval,
+		Coord:     coord,
+		MainValue: mainValue,
+		Position:  true,
+		ObsPos:    request.ObsPos,
+		Ayanamsha: ayanamsha,
+	}
+}
+
+// refine uses bisection to find the jd where the value of valueFunc changes sign, between jd1 and jd2.
+func refine(valueFunc func(jd float64) (float64, error), jd1, jd2 float64) (float64, error) {
+	value1, err := valueFunc(jd1)
+	if err != nil {
+		return 0.0, err
+	}
+	for i := 0; i < MaxRefineActions && jd2-jd1 > PrecisionJd; i++ {
+		jdMid := (jd1 + jd2) / 2.0
+		valueMid, err := valueFunc(jdMid)
+		if err != nil {
+			return 0.0, err
+		}
+		if (valueMid <= 0.0) == (value1 <= 0.0) {
+			jd1 = jdMid
+			value1 = valueMid
+		} else {
+			jd2 = jdMid
+		}
+	}
+	return (jd1 + jd2) / 2.0, nil
+}
+
+// arcDifference returns the shortest distance from pos2 to pos1, in the range -180.0 .. 180.0.
+func arcDifference(pos1, pos2 float64) float64 {
+	diff := math.Mod(pos1-pos2, 360.0)
+	if diff > 180.0 {
+		diff -= 360.0
+	}
+	if diff <= -180.0 {
+		diff += 360.0
+	}
+	return diff
+}
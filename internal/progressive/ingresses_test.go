@@ -0,0 +1,151 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package progressive
+
+import (
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+const delta = 0.00001
+
+func TestCalcSignIngressesHappyFlow(t *testing.T) {
+	ic := IngressCalculation{FakePointRangeCalculation{}, FakeSeEpsilonCalculation{}}
+	request := domain.IngressRequest{
+		Point:     domain.Mars,
+		JdStart:   1000.0,
+		JdEnd:     1010.0,
+		Interval:  1.0,
+		ObsPos:    domain.ObsPosGeocentric,
+		Ayanamsha: domain.AyanNone,
+	}
+	result, err := ic.CalcSignIngresses(request)
+	if err != nil {
+		t.Fatalf("CalcSignIngresses returned unexpected error %v", err)
+	}
+	// longitude 355 + 4 * days --> 0 Aries at day 1.25, 0 Taurus at day 8.75
+	if len(result) != 2 {
+		t.Fatalf("CalcSignIngresses expected 2 ingresses, got %d", len(result))
+	}
+	if result[0].Sign != domain.Aries || math.Abs(result[0].Jd-1001.25) > delta || result[0].Retrograde {
+		t.Errorf("CalcSignIngresses expected ingress in Aries at 1001.25, got %v", result[0])
+	}
+	if result[1].Sign != domain.Taurus || math.Abs(result[1].Jd-1008.75) > delta {
+		t.Errorf("CalcSignIngresses expected ingress in Taurus at 1008.75, got %v", result[1])
+	}
+}
+
+func TestCalcSignIngressesRetrograde(t *testing.T) {
+	ic := IngressCalculation{FakePointRangeCalculation{}, FakeSeEpsilonCalculation{}}
+	request := domain.IngressRequest{
+		Point:     domain.Saturn,
+		JdStart:   1000.0,
+		JdEnd:     1005.5,
+		Interval:  1.0,
+		ObsPos:    domain.ObsPosGeocentric,
+		Ayanamsha: domain.AyanNone,
+	}
+	result, err := ic.CalcSignIngresses(request)
+	if err != nil {
+		t.Fatalf("CalcSignIngresses returned unexpected error %v", err)
+	}
+	// longitude 62 - 1 * days --> back into Taurus at day 2
+	if len(result) != 1 {
+		t.Fatalf("CalcSignIngresses expected 1 ingress, got %d", len(result))
+	}
+	if result[0].Sign != domain.Taurus || math.Abs(result[0].Jd-1002.0) > delta || !result[0].Retrograde {
+		t.Errorf("CalcSignIngresses expected retrograde ingress in Taurus at 1002.0, got %v", result[0])
+	}
+}
+
+func TestCalcHouseIngresses(t *testing.T) {
+	ic := IngressCalculation{FakePointRangeCalculation{}, FakeSeEpsilonCalculation{}}
+	request := domain.IngressRequest{
+		Point:     domain.Mars,
+		JdStart:   1000.0,
+		JdEnd:     1010.0,
+		Interval:  1.0,
+		ObsPos:    domain.ObsPosGeocentric,
+		Ayanamsha: domain.AyanNone,
+	}
+	cusps := []float64{10.0, 40.0, 70.0, 100.0, 130.0, 160.0, 190.0, 220.0, 250.0, 280.0, 310.0, 340.0}
+	result, err := ic.CalcHouseIngresses(request, cusps)
+	if err != nil {
+		t.Fatalf("CalcHouseIngresses returned unexpected error %v", err)
+	}
+	// longitude 355 + 4 * days --> cusp 1 at day 3.75
+	if len(result) != 1 {
+		t.Fatalf("CalcHouseIngresses expected 1 ingress, got %d", len(result))
+	}
+	if result[0].House != 1 || math.Abs(result[0].Jd-1003.75) > delta {
+		t.Errorf("CalcHouseIngresses expected ingress in house 1 at 1003.75, got %v", result[0])
+	}
+}
+
+func TestCalcOobEvents(t *testing.T) {
+	ic := IngressCalculation{FakePointRangeCalculation{}, FakeSeEpsilonCalculation{}}
+	request := domain.IngressRequest{
+		Point:     domain.Moon,
+		JdStart:   1000.0,
+		JdEnd:     1010.0,
+		Interval:  0.5,
+		ObsPos:    domain.ObsPosGeocentric,
+		Ayanamsha: domain.AyanNone,
+	}
+	result, err := ic.CalcOobEvents(request)
+	if err != nil {
+		t.Fatalf("CalcOobEvents returned unexpected error %v", err)
+	}
+	// declination 20 + 1 * days, obliquity 23.5 --> entry at day 3.5
+	if len(result) != 1 {
+		t.Fatalf("CalcOobEvents expected 1 event, got %d", len(result))
+	}
+	if !result[0].Entry || !result[0].North || math.Abs(result[0].Jd-1003.5) > delta {
+		t.Errorf("CalcOobEvents expected entry in the north at 1003.5, got %v", result[0])
+	}
+	if math.Abs(result[0].Obliquity-23.5) > delta {
+		t.Errorf("CalcOobEvents expected obliquity 23.5, got %f", result[0].Obliquity)
+	}
+}
+
+func TestArcDifference(t *testing.T) {
+	if math.Abs(arcDifference(2.0, 358.0)-4.0) > delta {
+		t.Errorf("arcDifference expected 4.0, got %f", arcDifference(2.0, 358.0))
+	}
+	if math.Abs(arcDifference(358.0, 2.0)+4.0) > delta {
+		t.Errorf("arcDifference expected -4.0, got %f", arcDifference(358.0, 2.0))
+	}
+}
+
+// FakePointRangeCalculation uses linear movements: Mars moves direct, Saturn retrograde and the Moon is used for declination.
+type FakePointRangeCalculation struct{}
+
+func (fake FakePointRangeCalculation) CalcPointRange(request domain.PointRangeRequest) ([]domain.PointRangeResult, error) {
+	var results []domain.PointRangeResult
+	for jd := request.JdStart; jd <= request.JdEnd; jd += request.Interval {
+		days := jd - 1000.0
+		var value float64
+		switch request.Point {
+		case domain.Mars:
+			value = math.Mod(355.0+4.0*days, 360.0)
+		case domain.Saturn:
+			value = 62.0 - days
+		case domain.Moon:
+			value = 20.0 + days
+		}
+		results = append(results, domain.PointRangeResult{Jd: jd, Value: value})
+	}
+	return results, nil
+}
+
+type FakeSeEpsilonCalculation struct{}
+
+func (fake FakeSeEpsilonCalculation) CalcEpsilon(jdUt float64, trueEps bool) (float64, error) {
+	return 23.5, nil
+}
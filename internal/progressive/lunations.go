@@ -0,0 +1,143 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package progressive
+
+import (
+	"enigma-ar/domain"
+	"enigma-ar/internal/calc"
+	"enigma-ar/internal/se"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	LunarSampleInterval = 1.0  // days, the elongation increases between approx. 10 and 15 degrees per day
+	PhaseSize           = 45.0 // degrees, size of each of the eight lunar phases
+	LunationMargin      = 2.0  // days, added to the synodic month when searching for prenatal lunations
+)
+
+// LunationCalculator finds the moments that the Moon reaches specific elongations from the Sun and defines the
+// lunar phase for a chart.
+type LunationCalculator interface {
+	CalcElongationMoments(jdStart, jdEnd, elongation float64) ([]domain.LunarEvent, error)
+	CalcMainPhases(jdStart, jdEnd float64) ([]domain.LunarEvent, error)
+	CalcNatalPhase(jd float64) (domain.NatalLunarPhase, error)
+}
+
+type LunationCalculation struct {
+	sePointCalc se.SwephPointPosCalculator
+}
+
+func NewLunationCalculation() LunationCalculator {
+	ppc := se.NewSwephPointPosCalculation()
+	return LunationCalculation{ppc}
+}
+
+// CalcElongationMoments finds all moments in the given period that the Moon reaches the given elongation from the Sun.
+// PRE 0.0 <= elongation < 360.0
+// POST no errors -> returns events, sorted by jd. Errors: returns nil and error
+func (lc LunationCalculation) CalcElongationMoments(jdStart, jdEnd, elongation float64) ([]domain.LunarEvent, error) {
+	return lc.findElongations(jdStart, jdEnd, []float64{elongation})
+}
+
+// CalcMainPhases finds all new moons, first quarters, full moons and last quarters in the given period.
+// POST no errors -> returns events, sorted by jd. Errors: returns nil and error
+func (lc LunationCalculation) CalcMainPhases(jdStart, jdEnd float64) ([]domain.LunarEvent, error) {
+	return lc.findElongations(jdStart, jdEnd, []float64{0.0, 90.0, 180.0, 270.0})
+}
+
+// CalcNatalPhase defines the lunar phase for the given moment and finds the prenatal new moon and the prenatal full moon.
+// POST no errors -> returns the natal phase. Errors: returns empty result and error
+func (lc LunationCalculation) CalcNatalPhase(jd float64) (domain.NatalLunarPhase, error) {
+	var emptyResult domain.NatalLunarPhase
+	elongation, err := lc.elongation(jd)
+	if err != nil {
+		return emptyResult, err
+	}
+	phaseIndex := int(elongation / PhaseSize)
+	if phaseIndex >= len(domain.AllLunarPhases()) {
+		phaseIndex = len(domain.AllLunarPhases()) - 1
+	}
+	jdSearchStart := jd - domain.SynodicMonthInDays - LunationMargin
+	newMoons, err := lc.findElongations(jdSearchStart, jd, []float64{0.0})
+	if err != nil {
+		return emptyResult, err
+	}
+	fullMoons, err := lc.findElongations(jdSearchStart, jd, []float64{180.0})
+	if err != nil {
+		return emptyResult, err
+	}
+	if len(newMoons) == 0 || len(fullMoons) == 0 {
+		return emptyResult, fmt.Errorf("could not find prenatal lunations for jd %f", jd)
+	}
+	return domain.NatalLunarPhase{
+		Jd:                 jd,
+		Elongation:         elongation,
+		Phase:              domain.LunarPhase(phaseIndex),
+		JdPrenatalNewMoon:  newMoons[len(newMoons)-1].Jd,
+		JdPrenatalFullMoon: fullMoons[len(fullMoons)-1].Jd,
+	}, nil
+}
+
+// findElongations samples the elongation and refines each passage of one of the given elongations.
+func (lc LunationCalculation) findElongations(jdStart, jdEnd float64, elongations []float64) ([]domain.LunarEvent, error) {
+	events := make([]domain.LunarEvent, 0)
+	prevJd := jdStart
+	prevElong, err := lc.elongation(prevJd)
+	if err != nil {
+		return nil, err
+	}
+	for prevJd < jdEnd {
+		jd := math.Min(prevJd+LunarSampleInterval, jdEnd)
+		elong, err := lc.elongation(jd)
+		if err != nil {
+			return nil, err
+		}
+		step := arcDifference(elong, prevElong)
+		for _, target := range elongations {
+			dist := arcDifference(target, prevElong)
+			if dist <= 0.0 || dist > step {
+				continue
+			}
+			distanceToTarget := func(jd float64) (float64, error) {
+				actual, err := lc.elongation(jd)
+				if err != nil {
+					return 0.0, err
+				}
+				return arcDifference(actual, target), nil
+			}
+			jdExact, err := refine(distanceToTarget, prevJd, jd)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, domain.LunarEvent{Jd: jdExact, Elongation: target})
+		}
+		prevJd = jd
+		prevElong = elong
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Jd < events[j].Jd
+	})
+	return events, nil
+}
+
+// elongation returns the geocentric distance of the Moon to the Sun, measured in the direction of the zodiac.
+func (lc LunationCalculation) elongation(jd float64) (float64, error) {
+	allPoints := domain.AllChartPoints()
+	flags := domain.SeflgSwieph
+	posSun, err := lc.sePointCalc.CalcPointPos(jd, allPoints[domain.Sun].CalcId, flags)
+	if err != nil {
+		return 0.0, err
+	}
+	posMoon, err := lc.sePointCalc.CalcPointPos(jd, allPoints[domain.Moon].CalcId, flags)
+	if err != nil {
+		return 0.0, err
+	}
+	return calc.ValueToRange(posMoon[0]-posSun[0], 0.0, 360.0)
+}
@@ -0,0 +1,83 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package progressive
+
+import (
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+func TestCalcElongationMoments(t *testing.T) {
+	lc := LunationCalculation{FakeSePointPosCalculation{}}
+	result, err := lc.CalcElongationMoments(1000.0, 1070.0, 60.0)
+	if err != nil {
+		t.Fatalf("CalcElongationMoments returned unexpected error %v", err)
+	}
+	// elongation 12 * days --> 60 degrees at day 5, 35 and 65
+	expected := []float64{1005.0, 1035.0, 1065.0}
+	if len(result) != len(expected) {
+		t.Fatalf("CalcElongationMoments expected %d events, got %d", len(expected), len(result))
+	}
+	for i, jd := range expected {
+		if math.Abs(result[i].Jd-jd) > delta {
+			t.Errorf("CalcElongationMoments expected jd %f, got %f", jd, result[i].Jd)
+		}
+	}
+}
+
+func TestCalcMainPhases(t *testing.T) {
+	lc := LunationCalculation{FakeSePointPosCalculation{}}
+	result, err := lc.CalcMainPhases(1001.0, 1029.0)
+	if err != nil {
+		t.Fatalf("CalcMainPhases returned unexpected error %v", err)
+	}
+	expectedJds := []float64{1007.5, 1015.0, 1022.5}
+	expectedElongations := []float64{90.0, 180.0, 270.0}
+	if len(result) != len(expectedJds) {
+		t.Fatalf("CalcMainPhases expected %d events, got %d", len(expectedJds), len(result))
+	}
+	for i := range expectedJds {
+		if math.Abs(result[i].Jd-expectedJds[i]) > delta || result[i].Elongation != expectedElongations[i] {
+			t.Errorf("CalcMainPhases expected elongation %f at %f, got %v", expectedElongations[i], expectedJds[i], result[i])
+		}
+	}
+}
+
+func TestCalcNatalPhase(t *testing.T) {
+	lc := LunationCalculation{FakeSePointPosCalculation{}}
+	result, err := lc.CalcNatalPhase(1040.0)
+	if err != nil {
+		t.Fatalf("CalcNatalPhase returned unexpected error %v", err)
+	}
+	// elongation at day 40: 480 - 360 = 120 degrees
+	if math.Abs(result.Elongation-120.0) > delta {
+		t.Errorf("CalcNatalPhase expected elongation 120.0, got %f", result.Elongation)
+	}
+	if result.Phase != domain.PhaseFirstQuarter {
+		t.Errorf("CalcNatalPhase expected phase first quarter, got %d", result.Phase)
+	}
+	if math.Abs(result.JdPrenatalNewMoon-1030.0) > delta {
+		t.Errorf("CalcNatalPhase expected prenatal new moon at 1030.0, got %f", result.JdPrenatalNewMoon)
+	}
+	if math.Abs(result.JdPrenatalFullMoon-1015.0) > delta {
+		t.Errorf("CalcNatalPhase expected prenatal full moon at 1015.0, got %f", result.JdPrenatalFullMoon)
+	}
+}
+
+// FakeSePointPosCalculation uses linear movements: the Sun moves 1 degree per day and the Moon 13 degrees per day.
+type FakeSePointPosCalculation struct{}
+
+func (fake FakeSePointPosCalculation) CalcPointPos(jdUt float64, body int, flags int) ([6]float64, error) {
+	days := jdUt - 1000.0
+	speed := 1.0
+	if body == 1 {
+		speed = 13.0
+	}
+	return [6]float64{math.Mod(10.0+speed*days, 360.0), 0.0, 1.0, speed, 0.0, 0.0}, nil
+}
@@ -0,0 +1,190 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package progressive
+
+import (
+	"enigma-ar/domain"
+	"errors"
+	"math"
+)
+
+const (
+	ProfectionYear   = domain.TropicalYearInDays        // days, length of a profected year
+	ProfectionMonth  = domain.TropicalYearInDays / 12.0 // days, length of a profected month
+	FirdariaCycle    = 75                               // years, length of a complete cycle of the firdaria
+	FirdarSubPeriods = 7                                // number of sub-periods for the period of a planet
+	MonthsInYear     = 12
+	NrOfSigns        = 12
+	CycleMargin      = 1e-9 // fraction of a cycle, prevents rounding errors at the boundaries of a period
+)
+
+// TimeLordCalculator calculates the traditional time lords: annual and monthly profections and firdaria.
+type TimeLordCalculator interface {
+	CalcAnnualProfections(request domain.TimeLordRequest) ([]domain.Profection, error)
+	CalcMonthlyProfections(request domain.TimeLordRequest) ([]domain.Profection, error)
+	CalcFirdaria(request domain.TimeLordRequest) ([]domain.FirdarPeriod, error)
+}
+
+type TimeLordCalculation struct{}
+
+func NewTimeLordCalculation() TimeLordCalculator {
+	return TimeLordCalculation{}
+}
+
+// profectionLords are the traditional rulers of the signs, starting with Aries.
+var profectionLords = [12]domain.ChartPoint{
+	domain.Mars, domain.Venus, domain.Mercury, domain.Moon, domain.Sun, domain.Mercury,
+	domain.Venus, domain.Mars, domain.Jupiter, domain.Saturn, domain.Saturn, domain.Jupiter,
+}
+
+// firdar is a period of the firdaria, the length is in years. The south node is indicated with southNode.
+type firdar struct {
+	lord      domain.ChartPoint
+	southNode bool
+	years     int
+}
+
+var diurnalFirdaria = []firdar{
+	{domain.Sun, false, 10}, {domain.Venus, false, 8}, {domain.Mercury, false, 13}, {domain.Moon, false, 9},
+	{domain.Saturn, false, 11}, {domain.Jupiter, false, 12}, {domain.Mars, false, 7},
+	{domain.NodeMean, false, 3}, {domain.NodeMean, true, 2},
+}
+
+var nocturnalFirdaria = []firdar{
+	{domain.Moon, false, 9}, {domain.Saturn, false, 11}, {domain.Jupiter, false, 12}, {domain.Mars, false, 7},
+	{domain.Sun, false, 10}, {domain.Venus, false, 8}, {domain.Mercury, false, 13},
+	{domain.NodeMean, false, 3}, {domain.NodeMean, true, 2},
+}
+
+// chaldeanOrder is used for the sequence of the sub-periods, starting with the lord of the period.
+var chaldeanOrder = []domain.ChartPoint{
+	domain.Saturn, domain.Jupiter, domain.Mars, domain.Sun, domain.Venus, domain.Mercury, domain.Moon,
+}
+
+// CalcAnnualProfections returns the profected years that overlap the period in the request. The first year starts at
+// the radix with the sign of the ascendant, each following year moves one sign forward.
+// POST no errors -> returns profections, sorted by jd. Errors: returns nil and error
+func (tc TimeLordCalculation) CalcAnnualProfections(request domain.TimeLordRequest) ([]domain.Profection, error) {
+	ascSign := int(request.Radix.Asc.LonPos / SignSize)
+	profections := make([]domain.Profection, 0)
+	firstAge, lastAge := overlappingCycles(request, ProfectionYear)
+	for age := firstAge; age <= lastAge; age++ {
+		sign := domain.Sign((ascSign + age) % NrOfSigns)
+		jdStart := request.JdRadix + float64(age)*ProfectionYear
+		profections = append(profections, domain.Profection{
+			JdStart: jdStart,
+			JdEnd:   jdStart + ProfectionYear,
+			Age:     age,
+			Sign:    sign,
+			Lord:    profectionLords[sign],
+		})
+	}
+	return profections, nil
+}
+
+// CalcMonthlyProfections returns the profected months that overlap the period in the request. Each profected year is
+// divided in twelve equal months, the first month has the sign of the year and each following month moves one sign
+// forward.
+// POST no errors -> returns profections, sorted by jd. Errors: returns nil and error
+func (tc TimeLordCalculation) CalcMonthlyProfections(request domain.TimeLordRequest) ([]domain.Profection, error) {
+	ascSign := int(request.Radix.Asc.LonPos / SignSize)
+	profections := make([]domain.Profection, 0)
+	firstMonth, lastMonth := overlappingCycles(request, ProfectionMonth)
+	for monthCount := firstMonth; monthCount <= lastMonth; monthCount++ {
+		age := monthCount / MonthsInYear
+		sign := domain.Sign((ascSign + age + monthCount%MonthsInYear) % NrOfSigns)
+		jdStart := request.JdRadix + float64(monthCount)*ProfectionMonth
+		profections = append(profections, domain.Profection{
+			JdStart: jdStart,
+			JdEnd:   jdStart + ProfectionMonth,
+			Age:     age,
+			Month:   monthCount%MonthsInYear + 1,
+			Sign:    sign,
+			Lord:    profectionLords[sign],
+		})
+	}
+	return profections, nil
+}
+
+// CalcFirdaria returns the periods of the firdaria that overlap the period in the request, including all
+// sub-periods. The sequence depends on the radix being a day chart or a night chart. In both sequences the periods of
+// the nodes are at the end. After 75 years the sequence is repeated.
+// POST no errors -> returns periods, sorted by jd. Errors: returns nil and error
+func (tc TimeLordCalculation) CalcFirdaria(request domain.TimeLordRequest) ([]domain.FirdarPeriod, error) {
+	diurnal, err := isDiurnal(request.Radix)
+	if err != nil {
+		return nil, err
+	}
+	sequence := nocturnalFirdaria
+	if diurnal {
+		sequence = diurnalFirdaria
+	}
+	periods := make([]domain.FirdarPeriod, 0)
+	firstCycle, lastCycle := overlappingCycles(request, FirdariaCycle*ProfectionYear)
+	for cycle := firstCycle; cycle <= lastCycle; cycle++ {
+		jdStart := request.JdRadix + float64(cycle*FirdariaCycle)*ProfectionYear
+		for _, fd := range sequence {
+			jdEnd := jdStart + float64(fd.years)*ProfectionYear
+			if jdEnd > request.JdStart && jdStart < request.JdEnd {
+				periods = append(periods, domain.FirdarPeriod{
+					JdStart:    jdStart,
+					JdEnd:      jdEnd,
+					Lord:       fd.lord,
+					SouthNode:  fd.southNode,
+					SubPeriods: firdarSubPeriods(fd, jdStart, jdEnd),
+				})
+			}
+			jdStart = jdEnd
+		}
+	}
+	return periods, nil
+}
+
+// overlappingCycles returns the first and last index of the cycles, with the given length in days and starting at
+// the radix, that overlap the period in the request. Cycles before the radix are skipped. A cycle that only touches
+// the start or the end of the period, within CycleMargin, is not included.
+func overlappingCycles(request domain.TimeLordRequest, cycleLength float64) (int, int) {
+	first := int(math.Max(0.0, math.Floor((request.JdStart-request.JdRadix)/cycleLength+CycleMargin)))
+	last := int(math.Ceil((request.JdEnd-request.JdRadix)/cycleLength-CycleMargin)) - 1
+	return first, last
+}
+
+func firdarSubPeriods(fd firdar, jdStart, jdEnd float64) []domain.FirdarSubPeriod {
+	subPeriods := make([]domain.FirdarSubPeriod, 0, FirdarSubPeriods)
+	if fd.lord == domain.NodeMean {
+		return subPeriods
+	}
+	startIndex := 0
+	for i, lord := range chaldeanOrder {
+		if lord == fd.lord {
+			startIndex = i
+		}
+	}
+	subLength := (jdEnd - jdStart) / FirdarSubPeriods
+	for i := 0; i < FirdarSubPeriods; i++ {
+		subStart := jdStart + float64(i)*subLength
+		subPeriods = append(subPeriods, domain.FirdarSubPeriod{
+			JdStart: subStart,
+			JdEnd:   subStart + subLength,
+			Lord:    chaldeanOrder[(startIndex+i)%len(chaldeanOrder)],
+		})
+	}
+	return subPeriods
+}
+
+// isDiurnal returns true if the Sun is above the horizon: in the half of the ecliptic from the descendant,
+// through the MC, to the ascendant.
+func isDiurnal(radix domain.FullChartResponse) (bool, error) {
+	for _, point := range radix.Points {
+		if point.Point == domain.Sun {
+			arcFromAsc := math.Mod(point.LonPos-radix.Asc.LonPos+360.0, 360.0)
+			return arcFromAsc >= 180.0, nil
+		}
+	}
+	return false, errors.New("the Sun is missing in the radix")
+}
@@ -0,0 +1,130 @@
+/*
+ *  Enigma Astrology Research.
+ *  Copyright (c) Jan Kampherbeek.
+ *  Enigma is open source.
+ *  Please check the file copyright.txt in the root of the source for further details.
+ */
+
+package progressive
+
+import (
+	"enigma-ar/domain"
+	"math"
+	"testing"
+)
+
+const jdRadix = 2_451_545.0
+
+// timeLordRequest uses a radix with the ascendant at 15 Leo, the Sun is above the horizon if sunLon is 45.0.
+func timeLordRequest(sunLon, startYears, endYears float64) domain.TimeLordRequest {
+	return domain.TimeLordRequest{
+		Radix: domain.FullChartResponse{
+			Points: []domain.PointPosResult{{Point: domain.Sun, LonPos: sunLon}},
+			Asc:    domain.HousePosResult{LonPos: 135.0},
+		},
+		JdRadix: jdRadix,
+		JdStart: jdRadix + startYears*domain.TropicalYearInDays,
+		JdEnd:   jdRadix + endYears*domain.TropicalYearInDays,
+	}
+}
+
+func TestCalcAnnualProfections(t *testing.T) {
+	tc := TimeLordCalculation{}
+	result, err := tc.CalcAnnualProfections(timeLordRequest(45.0, 0.0, 2.5))
+	if err != nil {
+		t.Fatalf("CalcAnnualProfections returned unexpected error %v", err)
+	}
+	expected := []struct {
+		sign domain.Sign
+		lord domain.ChartPoint
+	}{{domain.Leo, domain.Sun}, {domain.Virgo, domain.Mercury}, {domain.Libra, domain.Venus}}
+	if len(result) != len(expected) {
+		t.Fatalf("CalcAnnualProfections expected %d profections, got %d", len(expected), len(result))
+	}
+	for i, exp := range expected {
+		if result[i].Age != i || result[i].Sign != exp.sign || result[i].Lord != exp.lord {
+			t.Errorf("CalcAnnualProfections expected %v and %v at age %d, got %v", exp.sign, exp.lord, i, result[i])
+		}
+	}
+	if math.Abs(result[1].JdStart-(jdRadix+domain.TropicalYearInDays)) > delta {
+		t.Errorf("CalcAnnualProfections expected start of second year at %f, got %f",
+			jdRadix+domain.TropicalYearInDays, result[1].JdStart)
+	}
+}
+
+func TestCalcAnnualProfectionsCycle(t *testing.T) {
+	tc := TimeLordCalculation{}
+	result, err := tc.CalcAnnualProfections(timeLordRequest(45.0, 12.2, 12.8))
+	if err != nil {
+		t.Fatalf("CalcAnnualProfections returned unexpected error %v", err)
+	}
+	if len(result) != 1 || result[0].Age != 12 || result[0].Sign != domain.Leo {
+		t.Errorf("CalcAnnualProfections expected Leo at age 12, got %v", result)
+	}
+}
+
+func TestCalcMonthlyProfections(t *testing.T) {
+	tc := TimeLordCalculation{}
+	result, err := tc.CalcMonthlyProfections(timeLordRequest(45.0, 1.0, 2.0))
+	if err != nil {
+		t.Fatalf("CalcMonthlyProfections returned unexpected error %v", err)
+	}
+	if len(result) != 12 {
+		t.Fatalf("CalcMonthlyProfections expected 12 months, got %d", len(result))
+	}
+	if result[0].Age != 1 || result[0].Month != 1 || result[0].Sign != domain.Virgo {
+		t.Errorf("CalcMonthlyProfections expected Virgo for the first month at age 1, got %v", result[0])
+	}
+	if result[11].Month != 12 || result[11].Sign != domain.Leo || result[11].Lord != domain.Sun {
+		t.Errorf("CalcMonthlyProfections expected Leo for the last month, got %v", result[11])
+	}
+}
+
+func TestCalcFirdariaDiurnal(t *testing.T) {
+	tc := TimeLordCalculation{}
+	result, err := tc.CalcFirdaria(timeLordRequest(45.0, 0.0, 11.0))
+	if err != nil {
+		t.Fatalf("CalcFirdaria returned unexpected error %v", err)
+	}
+	if len(result) != 2 || result[0].Lord != domain.Sun || result[1].Lord != domain.Venus {
+		t.Fatalf("CalcFirdaria expected the periods of the Sun and Venus, got %v", result)
+	}
+	expSubLords := []domain.ChartPoint{domain.Sun, domain.Venus, domain.Mercury, domain.Moon, domain.Saturn,
+		domain.Jupiter, domain.Mars}
+	for i, lord := range expSubLords {
+		if result[0].SubPeriods[i].Lord != lord {
+			t.Errorf("CalcFirdaria expected sub-lord %v at index %d, got %v", lord, i, result[0].SubPeriods[i].Lord)
+		}
+	}
+	expJdSecondSub := jdRadix + 10.0*domain.TropicalYearInDays/7.0
+	if math.Abs(result[0].SubPeriods[1].JdStart-expJdSecondSub) > delta {
+		t.Errorf("CalcFirdaria expected second sub-period at %f, got %f", expJdSecondSub, result[0].SubPeriods[1].JdStart)
+	}
+}
+
+func TestCalcFirdariaNocturnalWithNodes(t *testing.T) {
+	tc := TimeLordCalculation{}
+	result, err := tc.CalcFirdaria(timeLordRequest(225.0, 0.0, 75.5))
+	if err != nil {
+		t.Fatalf("CalcFirdaria returned unexpected error %v", err)
+	}
+	if len(result) != 10 || result[0].Lord != domain.Moon || result[9].Lord != domain.Moon {
+		t.Fatalf("CalcFirdaria expected 10 periods, starting with the Moon in both cycles, got %v", result)
+	}
+	southNode := result[8]
+	if southNode.Lord != domain.NodeMean || !southNode.SouthNode || len(southNode.SubPeriods) != 0 {
+		t.Errorf("CalcFirdaria expected the south node without sub-periods, got %v", southNode)
+	}
+	if math.Abs(result[9].JdStart-(jdRadix+75.0*domain.TropicalYearInDays)) > delta {
+		t.Errorf("CalcFirdaria expected the second cycle to start after 75 years, got %f", result[9].JdStart)
+	}
+}
+
+func TestCalcFirdariaSunMissing(t *testing.T) {
+	request := timeLordRequest(45.0, 0.0, 1.0)
+	request.Radix.Points = []domain.PointPosResult{}
+	tc := TimeLordCalculation{}
+	if _, err := tc.CalcFirdaria(request); err == nil {
+		t.Errorf("CalcFirdaria expected error for a radix without the Sun")
+	}
+}
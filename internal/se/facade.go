@@ -52,6 +52,11 @@ type SwephPointPosCalculator interface {
 	CalcPointPos(jdUt float64, body int, flags int) ([6]float64, error)
 }
 
+// SwephPlanetocentricCalculator retrieves the positions and speed as seen from another body (planetocentric).
+type SwephPlanetocentricCalculator interface {
+	CalcPointPosPctr(jdUt float64, body int, center int, flags int) ([6]float64, error)
+}
+
 // SwephEpsilonCalculator retrieves the value for the obliquity of the earths axis, either true (corrected for nutation) or mean.
 type SwephEpsilonCalculator interface {
 	CalcEpsilon(jdUt float64, trueEps bool) (float64, error)
@@ -59,7 +64,7 @@ type SwephEpsilonCalculator interface {
 
 // SwephHorPosCalculator retrieves the horizontal positions (azimuth and altitude) from the SE.
 type SwephHorPosCalculator interface {
-	CalcHorPos(jdUt float64, geoLong float64, geoLat float64, geoHeight float64, pointRa float64, pointDecl float64, flags int) [3]float64
+	CalcHorPos(jdUt float64, geoLong float64, geoLat float64, geoHeight float64, atPress float64, atTemp float64, pointRa float64, pointDecl float64, flags int) [3]float64
 }
 
 // SwephHousePosCalculator retrieves the housepositions and several other mundane points from the SE.
@@ -188,6 +193,41 @@ func (ppc SwephPointPosCalculation) CalcPointPos(jdUt float64, body int, flags i
 	return [6]float64(pos), nil
 }
 
+type SwephPlanetocentricCalculation struct{}
+
+func NewSwephPlanetocentricCalculation() SwephPlanetocentricCalculator {
+	return SwephPlanetocentricCalculation{}
+}
+
+// CalcPointPosPctr accesses the SE to calculate positions for celestial points as seen from the center body.
+// The SE uses ephemeris time for planetocentric positions, jdUt is converted using delta T.
+// The results are in the same sequence as for CalcPointPos.
+func (pc SwephPlanetocentricCalculation) CalcPointPosPctr(jdUt float64, body int, center int, flags int) ([6]float64, error) {
+	var cPos [6]C.double
+	cSerr := make([]C.char, C.AS_MAXCH)
+	cJdUt := C.double(jdUt)
+	cBody := C.int(body)
+	cCenter := C.int(center)
+	cFlags := C.int(flags)
+	// prepare SE
+	sep := string(filepath.Separator)
+	ephePath := ".." + sep + ".." + sep + "sedata" // path is relative from current package
+	sp := NewSwephPreparation()
+	sp.SetEphePath(ephePath)
+
+	cJdEt := cJdUt + C.swe_deltat_ex(cJdUt, cFlags, &cSerr[0])
+	result := C.swe_calc_pctr(cJdEt, cBody, cCenter, cFlags, &cPos[0], &cSerr[0])
+	if result < 0 {
+		var emptyArray [6]float64
+		return emptyArray, fmt.Errorf("CalcPointPosPctr error: %v", C.GoString(&cSerr[0]))
+	}
+	var pos [6]float64
+	for i := 0; i < 6; i++ {
+		pos[i] = float64(cPos[i])
+	}
+	return pos, nil
+}
+
 type SwephEpsilonCalculation struct{} // TODO create test for SwephEpsilonCalculation
 
 func NewSwephEpsilonCalculation() SwephEpsilonCalculator {
@@ -218,12 +258,14 @@ func NewSwephHorPosCalculation() SwephHorPosCalculator {
 }
 
 // CalcHorPos converts equatorial coordinates to azimuth, true altitude and apparent altitude. The SE does not return a result code.
-func (hpc SwephHorPosCalculation) CalcHorPos(jdUt float64, geoLong float64, geoLat float64, geoHeight float64, pointRa float64, pointDecl float64, flags int) [3]float64 {
+// The atmospheric pressure (hPa) and temperature (Celsius) are used for refraction. If atPress is zero, the SE estimates
+// the pressure from geoHeight.
+func (hpc SwephHorPosCalculation) CalcHorPos(jdUt float64, geoLong float64, geoLat float64, geoHeight float64, atPress float64, atTemp float64, pointRa float64, pointDecl float64, flags int) [3]float64 {
 	var cHorCoord [3]C.double
 	cJdUt := C.double(jdUt)
 	cFlags := C.int(flags)
-	cAtPress := C.double(0.0)
-	cAtTemp := C.double(0.0)
+	cAtPress := C.double(atPress)
+	cAtTemp := C.double(atTemp)
 	geoCoord := []float64{geoLong, geoLat, geoHeight}
 	pointCoord := []float64{pointRa, pointDecl}
 	cGeoCoord := (*C.double)(&geoCoord[0])
@@ -85,16 +85,43 @@ func TestPointPositions(t *testing.T) {
 	}
 }
 
+func TestPointPositionsPlanetocentricFromEarth(t *testing.T) {
+	sep := string(filepath.Separator)
+	ephePath := ".." + sep + ".." + sep + "sedata" // path is relative from current package
+	sp := NewSwephPreparation()
+	sp.SetEphePath(ephePath)
+	julDay := 2_470_000.0 // 2050/7/12 12:00
+	body := domain.AllChartPoints()[domain.Mercury].CalcId
+	center := domain.AllChartPoints()[domain.Earth].CalcId
+	flags := domain.SeflgSwieph + domain.SeflgSpeed
+	// seen from the earth, the planetocentric position equals the geocentric position
+	expected, err := SwephPointPosCalculation{}.CalcPointPos(julDay, body, flags)
+	if err != nil {
+		t.Fatalf("PointPositions(2_470_000, SeMercury, 256) returns error %s", err)
+	}
+	result, err := SwephPlanetocentricCalculation{}.CalcPointPosPctr(julDay, body, center, flags)
+	if err != nil {
+		t.Fatalf("PointPositionsPctr(2_470_000, SeMercury, SeEarth, 256) returns error %s", err)
+	}
+	for i := 0; i <= 2; i++ {
+		if math.Abs(result[i]-expected[i]) > 0.0001 {
+			t.Errorf("PointPositionsPctr(2_470_000, SeMercury, SeEarth, 256) = %f; want %f", result[i], expected[i])
+		}
+	}
+}
+
 func TestHorizontalPosition(t *testing.T) {
 	jdUt := 2_434_406.8177083335
 	geoLong := 6.9
 	geoLat := 52.216666666666669
 	geoHeight := 0.0
+	atPress := 0.0
+	atTemp := 0.0
 	pointRa := 317.18784726228648
 	pointDecl := -16.422932391786961
 	flags := 2048
 	expected := []float64{297.4812938568067, 0.0, 0.50662370470219853}
-	result := SwephHorPosCalculation{}.CalcHorPos(jdUt, geoLong, geoLat, geoHeight, pointRa, pointDecl, flags)
+	result := SwephHorPosCalculation{}.CalcHorPos(jdUt, geoLong, geoLat, geoHeight, atPress, atTemp, pointRa, pointDecl, flags)
 	for i := 0; i <= 2; i++ {
 		if math.Abs(result[i]-expected[i]) > DELTA {
 			t.Errorf("HorizontalPosition(2_434_406.8177, 6.9, 52.2166, 0.0, 0.0, 317.1878, -16.4229, 2048) = %f; want %f", result[i], expected[i])